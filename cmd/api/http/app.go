@@ -15,6 +15,7 @@ import (
     "github.com/AhmadNizar/cata-dtc/internal/infrastructure/cache"
     infrahttp "github.com/AhmadNizar/cata-dtc/internal/infrastructure/http"
     "github.com/AhmadNizar/cata-dtc/internal/infrastructure/db/mysql"
+    diskrepo "github.com/AhmadNizar/cata-dtc/internal/repository/disk"
     httprepo "github.com/AhmadNizar/cata-dtc/internal/repository/http"
     mysqlrepo "github.com/AhmadNizar/cata-dtc/internal/repository/mysql"
     redisrepo "github.com/AhmadNizar/cata-dtc/internal/repository/redis"
@@ -22,47 +23,14 @@ import (
 )
 
 func Start(cfg *config.Config) {
-    dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-        cfg.Database.User,
-        cfg.Database.Password,
-        cfg.Database.Host,
-        cfg.Database.Port,
-        cfg.Database.Name,
-    )
-
-    dbOption := &entity.MysqlDBConnOption{
-        URL:                 dsn,
-        MaxIdleConn:         "10",
-        MaxOpenConn:         "100",
-        MaxLifetimeInMinute: "5",
-    }
-
-    db := mysql.NewMysqlRepository(dbOption, cfg.App.Env)
-
-    redisClient, err := cache.NewRedisClient(cache.Config{
-        Host:     cfg.Redis.Host,
-        Port:     cfg.Redis.Port,
-        Password: cfg.Redis.Password,
-        DB:       cfg.Redis.DB,
-    })
-    if err != nil {
-        log.Fatalf("Failed to connect to Redis: %v", err)
+    // Every instance must sign cursors with the same key, or a cursor
+    // issued by one is rejected by the others and after a restart.
+    if cfg.App.CursorSecret == "" {
+        log.Fatal("❌ APP_CURSOR_SECRET must be set to sign pagination cursors")
     }
 
-    httpClient := infrahttp.NewHTTPClient(infrahttp.Config{
-        BaseURL:    cfg.Pokemon.BaseURL,
-        Timeout:    cfg.Pokemon.Timeout,
-        MaxRetries: cfg.Pokemon.MaxRetries,
-    })
-
-    pokemonRepo := mysqlrepo.NewPokemonRepository(db)
-    pokemonAPIRepo := httprepo.NewPokemonAPIRepository(httpClient, httprepo.Config{
-        BaseURL:    cfg.Pokemon.BaseURL,
-        MaxRetries: cfg.Pokemon.MaxRetries,
-    })
-    cacheRepo := redisrepo.NewCacheRepository(redisClient, "pokemon_api")
-    pokemonUseCase := pokemon.NewUsecase(pokemonRepo, pokemonAPIRepo, cacheRepo, cfg.Pokemon.CacheTTL)
-    apiHandler := handler.NewApiHandler(pokemonUseCase)
+    pokemonUseCase := newPokemonService(cfg)
+    apiHandler := handler.NewApiHandler(pokemonUseCase, cfg.App.Languages, cfg.App.CursorSecret)
 
     // Initialize background scheduler
     log.Println("📋 Initializing background job scheduler...")
@@ -109,4 +77,68 @@ func Start(cfg *config.Config) {
     scheduler.Stop()
     log.Println("✅ Background scheduler stopped")
     log.Println("✅ Application shutdown complete")
-}
\ No newline at end of file
+}
+
+// newPokemonService connects to MySQL, Redis and the upstream API and wires
+// them into the Pokemon service.
+func newPokemonService(cfg *config.Config) pokemon.Service {
+    dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+        cfg.Database.User,
+        cfg.Database.Password,
+        cfg.Database.Host,
+        cfg.Database.Port,
+        cfg.Database.Name,
+    )
+
+    dbOption := &entity.MysqlDBConnOption{
+        URL:                 dsn,
+        MaxIdleConn:         "10",
+        MaxOpenConn:         "100",
+        MaxLifetimeInMinute: "5",
+    }
+
+    db := mysql.NewMysqlRepository(dbOption, cfg.App.Env)
+
+    redisClient, err := cache.NewRedisClient(cache.Config{
+        Host:     cfg.Redis.Host,
+        Port:     cfg.Redis.Port,
+        Password: cfg.Redis.Password,
+        DB:       cfg.Redis.DB,
+    })
+    if err != nil {
+        log.Fatalf("Failed to connect to Redis: %v", err)
+    }
+
+    httpClient := infrahttp.NewHTTPClient(infrahttp.Config{
+        BaseURL:    cfg.Pokemon.BaseURL,
+        Timeout:    cfg.Pokemon.Timeout,
+        MaxRetries: cfg.Pokemon.MaxRetries,
+    })
+
+    pokemonRepo := mysqlrepo.NewPokemonRepository(db)
+    speciesRepo := mysqlrepo.NewPokemonSpeciesRepository(db)
+    evolutionChainRepo := mysqlrepo.NewEvolutionChainRepository(db)
+    moveRepo := mysqlrepo.NewMoveRepository(db)
+    pokemonMoveRepo := mysqlrepo.NewPokemonMoveRepository(db)
+    typeRepo := mysqlrepo.NewTypeRepository(db)
+    pokemonAbilityRepo := mysqlrepo.NewPokemonAbilityRepository(db)
+    pokemonHistoryRepo := mysqlrepo.NewPokemonHistoryRepository(db)
+    syncRunRepo := mysqlrepo.NewSyncRunRepository(db)
+    fenceRepo := mysqlrepo.NewFenceRepository(db)
+    pokemonAPIRepo := httprepo.NewPokemonAPIRepository(httpClient, httprepo.Config{
+        BaseURL:           cfg.Pokemon.BaseURL,
+        MaxRetries:        cfg.Pokemon.MaxRetries,
+        RequestsPerSecond: cfg.Pokemon.RateLimit,
+    })
+    cacheRepo := redisrepo.NewCacheRepository(redisClient, "pokemon_api")
+    lockRepo := redisrepo.NewLockRepository(redisClient, "pokemon_api")
+    spriteRepo := diskrepo.NewBlobRepository(cfg.Pokemon.SpriteDir)
+    return pokemon.NewUsecase(pokemonRepo, pokemonAPIRepo, speciesRepo, evolutionChainRepo, moveRepo, pokemonMoveRepo, typeRepo, pokemonAbilityRepo, pokemonHistoryRepo, syncRunRepo, lockRepo, fenceRepo, cacheRepo, spriteRepo, cfg.Pokemon.CacheTTL, cfg.App.Languages, pokemon.SyncConfig{
+        StartID:     cfg.Pokemon.SyncStartID,
+        EndID:       cfg.Pokemon.SyncEndID,
+        PageSize:    cfg.Pokemon.SyncPageSize,
+        IDs:         cfg.Pokemon.SyncIDs,
+        Concurrency: cfg.Pokemon.SyncConcurrency,
+        LockTTL:     cfg.Pokemon.SyncLockTTL,
+    })
+}
@@ -1,10 +1,17 @@
 package handler
 
 import (
+    "errors"
+    "fmt"
     "net/http"
+    "strconv"
+    "strings"
+    "time"
 
     "github.com/AhmadNizar/cata-dtc/internal/dto"
+    "github.com/AhmadNizar/cata-dtc/internal/entity"
     "github.com/AhmadNizar/cata-dtc/internal/presenter"
+    "github.com/AhmadNizar/cata-dtc/internal/repository"
     "github.com/AhmadNizar/cata-dtc/internal/usecase/pokemon"
     "github.com/gin-gonic/gin"
 )
@@ -12,30 +19,209 @@ import (
 // ApiHandler handles API integration HTTP requests
 type ApiHandler struct {
     pokemonService pokemon.Service
+    languages      []string
+    cursors        cursorCodec
 }
 
-// NewApiHandler returns a new ApiHandler
-func NewApiHandler(pokemonService pokemon.Service) *ApiHandler {
-    return &ApiHandler{pokemonService: pokemonService}
+// NewApiHandler returns a new ApiHandler that serves localized names in
+// the given languages and signs pagination cursors with cursorSecret.
+func NewApiHandler(pokemonService pokemon.Service, languages []string, cursorSecret string) *ApiHandler {
+    return &ApiHandler{
+        pokemonService: pokemonService,
+        languages:      languages,
+        cursors:        newCursorCodec(cursorSecret),
+    }
 }
 
+// Sync starts a background sync and returns its job right away. If a sync
+// is already running here, that job is returned instead of starting
+// another; if it is running on another instance, the request conflicts.
 func (ah *ApiHandler) Sync(c *gin.Context) {
-    err := ah.pokemonService.SyncPokemonData()
+    job, started, err := ah.pokemonService.StartSyncJob(entity.SyncTriggerAPI)
+    if errors.Is(err, pokemon.ErrSyncInProgress) {
+        c.JSON(http.StatusConflict, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "Pokemon data sync already in progress on another instance",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to start pokemon data sync",
+        })
+        return
+    }
+
+    message := "Pokemon data sync started"
+    if !started {
+        message = "Pokemon data sync already in progress"
+    }
+
+    c.Header("Location", "/api/v1/sync/jobs/"+job.ID)
+    c.JSON(http.StatusAccepted, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: message,
+        Data:    toSyncJobPresenter(job),
+    })
+}
+
+// DryRunSync previews a sync without writing anything. The optional ids
+// query parameter (e.g. ids=1,4,7) narrows it to specific Pokemon.
+func (ah *ApiHandler) DryRunSync(c *gin.Context) {
+    var pokemonIDs []int
+    if raw := c.Query("ids"); raw != "" {
+        for _, part := range strings.Split(raw, ",") {
+            id, err := strconv.Atoi(strings.TrimSpace(part))
+            if err != nil || id < 1 {
+                c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+                    OK:      false,
+                    Message: "ids must be a comma-separated list of positive integers",
+                })
+                return
+            }
+            pokemonIDs = append(pokemonIDs, id)
+        }
+    }
+
+    report, err := ah.pokemonService.DryRunSync(pokemonIDs)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to preview pokemon data sync",
+        })
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully previewed pokemon data sync",
+        Data:    toSyncDryRunPresenter(report),
+    })
+}
+
+func (ah *ApiHandler) GetSyncJob(c *gin.Context) {
+    job := ah.pokemonService.GetSyncJob(c.Param("id"))
+    if job == nil {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "sync job not found",
+        })
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully get sync job",
+        Data:    toSyncJobPresenter(job),
+    })
+}
+
+// SyncPokemon refreshes a single Pokemon, looked up upstream by ID or name.
+// It conflicts with a sync running on any instance.
+func (ah *ApiHandler) SyncPokemon(c *gin.Context) {
+    synced, result, err := ah.pokemonService.SyncSinglePokemon(c.Param("idOrName"))
+    if errors.Is(err, pokemon.ErrPokemonNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found upstream",
+        })
+        return
+    }
+    if errors.Is(err, pokemon.ErrSyncInProgress) {
+        c.JSON(http.StatusConflict, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "Pokemon data sync already in progress",
+        })
+        return
+    }
+    if errors.Is(err, pokemon.ErrSyncLeaseLost) {
+        c.JSON(http.StatusConflict, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "sync lock was taken over by another instance",
+        })
+        return
+    }
     if err != nil {
         c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
             OK:      false,
-            Message: "failed to sync pokemon data",
+            Message: "failed to sync pokemon",
         })
         return
     }
+
     c.JSON(http.StatusOK, dto.GeneralResponseDTO{
         OK:      true,
-        Message: "Successfully synced pokemon data",
+        Message: "Successfully synced pokemon",
+        Data: presenter.PokemonSyncResult{
+            Result:  string(result),
+            Pokemon: toPokemonPresenter(synced, newLocalizer(entity.DefaultLanguage)),
+        },
     })
 }
 
+// GetItems lists every Pokemon, with names in the negotiated language.
 func (ah *ApiHandler) GetItems(c *gin.Context) {
-    pokemons, total, err := ah.pokemonService.GetPokemonItems()
+    l10n := ah.negotiateLanguage(c)
+
+    filter, err := parsePokemonFilter(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    order, err := parsePokemonSort(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    token, err := cursorParam(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    // A cursor switches from offset to keyset pagination
+    var after *repository.PokemonCursor
+    if token != "" {
+        cursor, err := ah.cursors.decode(token, order)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+                OK:      false,
+                Message: err.Error(),
+            })
+            return
+        }
+        after = &cursor
+    }
+
+    var pokemons []*entity.Pokemon
+    var total int64
+    var next *repository.PokemonCursor
+    if after != nil {
+        pokemons, total, next, err = ah.pokemonService.GetPokemonItemsAfter(l10n.language, filter, order, *after, limit)
+    } else {
+        pokemons, total, next, err = ah.pokemonService.GetPokemonItems(l10n.language, filter, order, limit, (page-1)*limit)
+    }
     if err != nil {
         c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
             OK:      false,
@@ -47,47 +233,1139 @@ func (ah *ApiHandler) GetItems(c *gin.Context) {
     // Convert entities to presenter format
     items := make([]presenter.Pokemon, len(pokemons))
     for i, pokemon := range pokemons {
-        // Convert types
-        types := make([]presenter.PokemonType, len(pokemon.Types))
-        for j, pokemonType := range pokemon.Types {
-            types[j] = presenter.PokemonType{
-                Name: pokemonType.TypeName,
-            }
+        items[i] = toPokemonPresenter(pokemon, l10n)
+    }
+
+    var nextCursor *string
+    if next != nil {
+        token := ah.cursors.encode(*next, order)
+        nextCursor = &token
+    }
+
+    pages := totalPages(total, limit)
+    result := presenter.PokemonList{
+        Items:      items,
+        Total:      total,
+        Limit:      limit,
+        TotalPages: pages,
+        NextCursor: nextCursor,
+    }
+    if after != nil {
+        result.Links = cursorLinks(c, nextCursor)
+    } else {
+        result.Page = page
+        result.Links = pageLinks(c, page, pages)
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:       true,
+        Message:  "Successfully get pokemon data",
+        Language: l10n.contentLanguage(c),
+        Data:     result,
+    })
+}
+
+// GetItem returns one Pokemon by ID, with names in the negotiated
+// language.
+func (ah *ApiHandler) GetItem(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid pokemon id",
+        })
+        return
+    }
+
+    l10n := ah.negotiateLanguage(c)
+    item, err := ah.pokemonService.GetPokemonByID(l10n.language, uint(id))
+    ah.respondPokemonItem(c, l10n, item, err)
+}
+
+// GetItemByName returns one Pokemon by name. Names are matched case
+// insensitively as slugs, so "Mr. Mime" finds mr-mime.
+func (ah *ApiHandler) GetItemByName(c *gin.Context) {
+    l10n := ah.negotiateLanguage(c)
+    item, err := ah.pokemonService.GetPokemonByName(l10n.language, c.Param("name"))
+    ah.respondPokemonItem(c, l10n, item, err)
+}
+
+func (ah *ApiHandler) respondPokemonItem(c *gin.Context, l10n *localizer, item *entity.Pokemon, err error) {
+    if errors.Is(err, pokemon.ErrPokemonNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch pokemon data",
+        })
+        return
+    }
+
+    data := toPokemonPresenter(item, l10n)
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:       true,
+        Message:  "Successfully get pokemon data",
+        Language: l10n.contentLanguage(c),
+        Data:     data,
+    })
+}
+
+// GetItemSprite serves a sprite image from local storage. The image behind
+// a URL only changes if upstream replaces it, so it is cached for a long
+// time and revalidated by ETag.
+func (ah *ApiHandler) GetItemSprite(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid pokemon id",
+        })
+        return
+    }
+
+    sprite, err := ah.pokemonService.GetPokemonSprite(uint(id), c.Param("variant"))
+    switch {
+    case errors.Is(err, pokemon.ErrInvalidSpriteVariant):
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "variant must be one of: " + strings.Join(entity.SpriteVariants, ", "),
+        })
+        return
+    case errors.Is(err, pokemon.ErrPokemonNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found",
+        })
+        return
+    case errors.Is(err, pokemon.ErrSpriteNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "sprite not found",
+        })
+        return
+    case err != nil:
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch sprite",
+        })
+        return
+    }
+
+    etag := `"` + sprite.Key + `"`
+    c.Header("Cache-Control", "public, max-age=31536000")
+    c.Header("ETag", etag)
+    if c.GetHeader("If-None-Match") == etag {
+        c.Status(http.StatusNotModified)
+        return
+    }
+
+    c.Data(http.StatusOK, sprite.ContentType, sprite.Data)
+}
+
+// GetItemEvolutions returns the evolution chain of a Pokemon as a tree.
+func (ah *ApiHandler) GetItemEvolutions(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid pokemon id",
+        })
+        return
+    }
+
+    l10n := ah.negotiateLanguage(c)
+    chain, err := ah.pokemonService.GetPokemonEvolutions(uint(id), l10n.language)
+    switch {
+    case errors.Is(err, pokemon.ErrPokemonNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found",
+        })
+        return
+    case errors.Is(err, pokemon.ErrEvolutionsNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "evolution chain not synced yet",
+        })
+        return
+    case err != nil:
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch evolutions",
+        })
+        return
+    }
+
+    data := toEvolutionChainPresenter(chain, l10n)
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:       true,
+        Message:  "Successfully get pokemon evolutions",
+        Language: l10n.contentLanguage(c),
+        Data:     data,
+    })
+}
+
+// GetItemMoves returns the learnset of a Pokemon. It can be narrowed with
+// the learn_method and version_group query parameters.
+func (ah *ApiHandler) GetItemMoves(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid pokemon id",
+        })
+        return
+    }
+
+    pokemonMoves, err := ah.pokemonService.GetPokemonMoves(uint(id), parsePokemonMoveFilter(c))
+    if errors.Is(err, pokemon.ErrPokemonNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch pokemon moves",
+        })
+        return
+    }
+
+    items := make([]presenter.PokemonMove, len(pokemonMoves))
+    for i, pokemonMove := range pokemonMoves {
+        items[i] = presenter.PokemonMove{
+            LearnMethod:  pokemonMove.LearnMethod,
+            Level:        pokemonMove.Level,
+            VersionGroup: pokemonMove.VersionGroup,
         }
+        if pokemonMove.Move != nil {
+            items[i].Move = toMovePresenter(pokemonMove.Move)
+        }
+    }
 
-        // Convert abilities
-        abilities := make([]presenter.PokemonAbility, len(pokemon.Abilities))
-        for j, pokemonAbility := range pokemon.Abilities {
-            abilities[j] = presenter.PokemonAbility{
-                Name:     pokemonAbility.AbilityName,
-                IsHidden: pokemonAbility.IsHidden,
-            }
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully get pokemon moves",
+        Data: presenter.PokemonLearnset{
+            PokemonID: uint(id),
+            Moves:     items,
+        },
+    })
+}
+
+// GetMovePokemon lists the Pokemon that can learn a move. It takes the
+// same filters as GetItemMoves, plus page and limit or a cursor.
+func (ah *ApiHandler) GetMovePokemon(c *gin.Context) {
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    token, err := cursorParam(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    // A cursor switches from offset to keyset pagination
+    var after *uint
+    if token != "" {
+        afterID, err := ah.cursors.decodeMovePokemon(token)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+                OK:      false,
+                Message: err.Error(),
+            })
+            return
         }
+        after = &afterID
+        page = 0
+    }
+
+    var move *entity.Move
+    var pokemons []*entity.Pokemon
+    var total int64
+    var next *uint
+    filter := parsePokemonMoveFilter(c)
+    if after != nil {
+        move, pokemons, total, next, err = ah.pokemonService.GetMoveLearnersAfter(c.Param("name"), filter, *after, limit)
+    } else {
+        move, pokemons, total, next, err = ah.pokemonService.GetMoveLearners(c.Param("name"), filter, limit, (page-1)*limit)
+    }
+    if errors.Is(err, pokemon.ErrMoveNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "move not found",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch pokemon for move",
+        })
+        return
+    }
 
-        items[i] = presenter.Pokemon{
-            ID:        pokemon.ID,
-            Name:      pokemon.Name,
-            Height:    pokemon.Height,
-            Weight:    pokemon.Weight,
-            BaseExp:   pokemon.BaseExp,
-            Order:     pokemon.OrderNum,
-            Types:     types,
-            Abilities: abilities,
-            CreatedAt: pokemon.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-            UpdatedAt: pokemon.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+    items := make([]presenter.PokemonSummary, len(pokemons))
+    for i, learner := range pokemons {
+        items[i] = presenter.PokemonSummary{
+            ID:   learner.ID,
+            Name: learner.Name,
         }
     }
 
-    result := presenter.PokemonList{
-        Items: items,
-        Total: total,
-        Page:  1,
-        Limit: len(items),
+    var nextCursor *string
+    if next != nil {
+        token := ah.cursors.encodeMovePokemon(*next)
+        nextCursor = &token
     }
 
     c.JSON(http.StatusOK, dto.GeneralResponseDTO{
         OK:      true,
-        Message: "Successfully get pokemon data",
-        Data:    result,
+        Message: "Successfully get pokemon for move",
+        Data: presenter.MoveLearners{
+            Move:       toMovePresenter(move),
+            Items:      items,
+            Total:      total,
+            Page:       page,
+            Limit:      limit,
+            NextCursor: nextCursor,
+        },
     })
-}
\ No newline at end of file
+}
+
+// GetAbilities lists abilities by name, paginated with page and limit or
+// a cursor.
+func (ah *ApiHandler) GetAbilities(c *gin.Context) {
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    token, err := cursorParam(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+    l10n := ah.negotiateLanguage(c)
+
+    // A cursor switches from offset to keyset pagination
+    var after *string
+    if token != "" {
+        name, err := ah.cursors.decodeAbility(token)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+                OK:      false,
+                Message: err.Error(),
+            })
+            return
+        }
+        after = &name
+        page = 0
+    }
+
+    var abilities []*entity.Ability
+    var total int64
+    var next *string
+    if after != nil {
+        abilities, total, next, err = ah.pokemonService.ListAbilitiesAfter(l10n.language, *after, limit)
+    } else {
+        abilities, total, next, err = ah.pokemonService.ListAbilities(l10n.language, limit, (page-1)*limit)
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch abilities",
+        })
+        return
+    }
+
+    items := make([]presenter.Ability, len(abilities))
+    for i, ability := range abilities {
+        items[i] = toAbilityPresenter(ability, l10n)
+    }
+
+    var nextCursor *string
+    if next != nil {
+        token := ah.cursors.encodeAbility(*next)
+        nextCursor = &token
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:       true,
+        Message:  "Successfully get abilities",
+        Language: l10n.contentLanguage(c),
+        Data: presenter.AbilityList{
+            Items:      items,
+            Total:      total,
+            Page:       page,
+            Limit:      limit,
+            NextCursor: nextCursor,
+        },
+    })
+}
+
+// GetAbility returns an ability with a page of the Pokemon that have it.
+func (ah *ApiHandler) GetAbility(c *gin.Context) {
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+    l10n := ah.negotiateLanguage(c)
+
+    ability, pokemonAbilities, total, err := ah.pokemonService.GetAbility(c.Param("name"), l10n.language, limit, (page-1)*limit)
+    if errors.Is(err, pokemon.ErrAbilityNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "ability not found",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch ability",
+        })
+        return
+    }
+
+    items := make([]presenter.AbilityPokemon, len(pokemonAbilities))
+    for i, pokemonAbility := range pokemonAbilities {
+        items[i] = presenter.AbilityPokemon{
+            ID:          pokemonAbility.PokemonID,
+            Name:        pokemonAbility.Pokemon.Name,
+            DisplayName: pokemonDisplayName(&pokemonAbility.Pokemon, l10n),
+            IsHidden:    pokemonAbility.IsHidden,
+            Slot:        pokemonAbility.Slot,
+        }
+    }
+
+    abilityData := toAbilityPresenter(ability, l10n)
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:       true,
+        Message:  "Successfully get ability",
+        Language: l10n.contentLanguage(c),
+        Data: presenter.AbilityDetail{
+            Ability: abilityData,
+            Items:   items,
+            Total:   total,
+            Page:    page,
+            Limit:   limit,
+        },
+    })
+}
+
+// GetTypeMatchups returns the damage relations of a type, attacking and
+// defending.
+func (ah *ApiHandler) GetTypeMatchups(c *gin.Context) {
+    l10n := ah.negotiateLanguage(c)
+    pokemonType, relations, err := ah.pokemonService.GetTypeMatchups(c.Param("name"), l10n.language)
+    switch {
+    case errors.Is(err, pokemon.ErrTypeNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "type not found",
+        })
+        return
+    case errors.Is(err, pokemon.ErrDamageRelationsNotSynced):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "type damage relations not synced yet",
+        })
+        return
+    case err != nil:
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch type matchups",
+        })
+        return
+    }
+
+    data := toTypeMatchupsPresenter(pokemonType, relations, l10n)
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:       true,
+        Message:  "Successfully get type matchups",
+        Language: l10n.contentLanguage(c),
+        Data:     data,
+    })
+}
+
+// CalculateMatchup returns the damage multiplier of an attacking type
+// against a defender, given either as a stored Pokemon or as its types.
+func (ah *ApiHandler) CalculateMatchup(c *gin.Context) {
+    var req dto.MatchupRequestDTO
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "attacking_type is required and defending_types must list one or two types",
+        })
+        return
+    }
+    if (req.DefendingPokemon == "") == (len(req.DefendingTypes) == 0) {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "exactly one of defending_pokemon and defending_types is required",
+        })
+        return
+    }
+
+    matchup, err := ah.pokemonService.CalculateMatchup(req.AttackingType, req.DefendingPokemon, req.DefendingTypes)
+    switch {
+    case errors.Is(err, pokemon.ErrTypeNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "type not found",
+        })
+        return
+    case errors.Is(err, pokemon.ErrPokemonNotFound):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found",
+        })
+        return
+    case errors.Is(err, pokemon.ErrDamageRelationsNotSynced):
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "type damage relations not synced yet",
+        })
+        return
+    case errors.Is(err, pokemon.ErrInvalidMatchup):
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "the defender must have one or two types",
+        })
+        return
+    case err != nil:
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to calculate matchup",
+        })
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully calculated matchup",
+        Data:    toMatchupPresenter(matchup),
+    })
+}
+
+// GetTombstonedPokemon lists the Pokemon tombstoned because they
+// disappeared upstream, most recently tombstoned first.
+func (ah *ApiHandler) GetTombstonedPokemon(c *gin.Context) {
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    pokemons, total, err := ah.pokemonService.ListTombstonedPokemon(limit, (page-1)*limit)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch tombstoned pokemon",
+        })
+        return
+    }
+
+    items := make([]presenter.TombstonedPokemon, len(pokemons))
+    for i, tombstoned := range pokemons {
+        items[i] = presenter.TombstonedPokemon{
+            ID:        tombstoned.ID,
+            Name:      tombstoned.Name,
+            DeletedAt: tombstoned.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+        }
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully get tombstoned pokemon",
+        Data: presenter.TombstonedPokemonList{
+            Items: items,
+            Total: total,
+            Page:  page,
+            Limit: limit,
+        },
+    })
+}
+
+// RestorePokemon clears the tombstone of a Pokemon so that reads show it
+// again.
+func (ah *ApiHandler) RestorePokemon(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid pokemon id",
+        })
+        return
+    }
+
+    restored, err := ah.pokemonService.RestorePokemon(uint(id))
+    if errors.Is(err, pokemon.ErrPokemonNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "tombstoned pokemon not found",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to restore pokemon",
+        })
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully restored pokemon",
+        Data: presenter.PokemonSummary{
+            ID:   restored.ID,
+            Name: restored.Name,
+        },
+    })
+}
+
+// GetItemHistory returns the change log of a Pokemon, newest version
+// first.
+func (ah *ApiHandler) GetItemHistory(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid pokemon id",
+        })
+        return
+    }
+
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    history, total, err := ah.pokemonService.GetPokemonHistory(uint(id), limit, (page-1)*limit)
+    if errors.Is(err, pokemon.ErrPokemonNotFound) {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "pokemon not found",
+        })
+        return
+    }
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch pokemon history",
+        })
+        return
+    }
+
+    items := make([]presenter.PokemonHistoryEntry, len(history))
+    for i, entry := range history {
+        items[i] = toPokemonHistoryPresenter(entry)
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully get pokemon history",
+        Data: presenter.PokemonHistory{
+            PokemonID: uint(id),
+            Items:     items,
+            Total:     total,
+            Page:      page,
+            Limit:     limit,
+        },
+    })
+}
+
+func (ah *ApiHandler) GetSyncRuns(c *gin.Context) {
+    page, limit, err := parsePageParams(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: err.Error(),
+        })
+        return
+    }
+
+    runs, total, err := ah.pokemonService.ListSyncRuns(limit, (page-1)*limit)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch sync runs",
+        })
+        return
+    }
+
+    items := make([]presenter.SyncRun, len(runs))
+    for i, run := range runs {
+        items[i] = toSyncRunPresenter(run)
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully get sync runs",
+        Data: presenter.SyncRunList{
+            Items: items,
+            Total: total,
+            Page:  page,
+            Limit: limit,
+        },
+    })
+}
+
+func (ah *ApiHandler) GetSyncRun(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "invalid sync run id",
+        })
+        return
+    }
+
+    run, err := ah.pokemonService.GetSyncRun(uint(id))
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "failed to fetch sync run",
+        })
+        return
+    }
+    if run == nil {
+        c.JSON(http.StatusNotFound, dto.GeneralResponseDTO{
+            OK:      false,
+            Message: "sync run not found",
+        })
+        return
+    }
+
+    c.JSON(http.StatusOK, dto.GeneralResponseDTO{
+        OK:      true,
+        Message: "Successfully get sync run",
+        Data:    toSyncRunPresenter(run),
+    })
+}
+
+func toPokemonPresenter(pokemon *entity.Pokemon, l10n *localizer) presenter.Pokemon {
+    // Convert types
+    types := make([]presenter.PokemonType, len(pokemon.Types))
+    for i, pokemonType := range pokemon.Types {
+        translation, ok := l10n.translate(&pokemonType.Type)
+        types[i] = presenter.PokemonType{
+            ID:          pokemonType.TypeID,
+            Name:        pokemonType.Type.Name,
+            DisplayName: localizedName(translation, ok, pokemonType.Type.Name),
+            Slot:        pokemonType.Slot,
+        }
+    }
+
+    // Convert abilities
+    abilities := make([]presenter.PokemonAbility, len(pokemon.Abilities))
+    for i, pokemonAbility := range pokemon.Abilities {
+        translation, ok := l10n.translate(&pokemonAbility.Ability)
+        abilities[i] = presenter.PokemonAbility{
+            ID:          pokemonAbility.AbilityID,
+            Name:        pokemonAbility.Ability.Name,
+            DisplayName: localizedName(translation, ok, pokemonAbility.Ability.Name),
+            IsHidden:    pokemonAbility.IsHidden,
+            Slot:        pokemonAbility.Slot,
+        }
+    }
+
+    // Convert stats
+    stats := make([]presenter.PokemonStat, len(pokemon.Stats))
+    for i, pokemonStat := range pokemon.Stats {
+        stats[i] = presenter.PokemonStat{
+            Name:     pokemonStat.StatName,
+            BaseStat: pokemonStat.BaseStat,
+            Effort:   pokemonStat.Effort,
+        }
+    }
+
+    var flavorText string
+    if pokemon.Species != nil {
+        if translation, ok := l10n.translate(pokemon.Species); ok {
+            flavorText = translation.Description
+        }
+    }
+
+    return presenter.Pokemon{
+        ID:          pokemon.ID,
+        Name:        pokemon.Name,
+        DisplayName: pokemonDisplayName(pokemon, l10n),
+        FlavorText:  flavorText,
+        Height:      pokemon.Height,
+        Weight:      pokemon.Weight,
+        BaseExp:     pokemon.BaseExp,
+        Order:       pokemon.OrderNum,
+        IsDefault:   pokemon.IsDefault,
+        FormName:    pokemon.FormName,
+        Types:       types,
+        Abilities:   abilities,
+        Stats:       stats,
+        Sprites:     toPokemonSpritesPresenter(pokemon),
+        CreatedAt:   pokemon.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+        UpdatedAt:   pokemon.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+    }
+}
+
+// pokemonDisplayName returns the localized name of a Pokemon's species,
+// or the Pokemon's slug when its species has not been loaded.
+func pokemonDisplayName(pokemon *entity.Pokemon, l10n *localizer) string {
+    if pokemon.Species == nil {
+        return pokemon.Name
+    }
+    translation, ok := l10n.translate(pokemon.Species)
+    return localizedName(translation, ok, pokemon.Name)
+}
+
+// toPokemonSpritesPresenter points each sprite at this API's sprite
+// endpoint rather than the upstream CDN.
+func toPokemonSpritesPresenter(pokemon *entity.Pokemon) presenter.PokemonSprites {
+    spriteURL := func(variant string) *string {
+        if upstreamURL, _ := pokemon.Sprites.URL(variant); upstreamURL == "" {
+            return nil
+        }
+        url := fmt.Sprintf("/api/v1/items/%d/sprite/%s", pokemon.ID, variant)
+        return &url
+    }
+
+    return presenter.PokemonSprites{
+        FrontDefault: spriteURL("front_default"),
+        BackDefault:  spriteURL("back_default"),
+        FrontShiny:   spriteURL("front_shiny"),
+        BackShiny:    spriteURL("back_shiny"),
+    }
+}
+
+// toEvolutionChainPresenter rebuilds the tree from the chain's flat links,
+// keeping siblings in their stored order.
+func toEvolutionChainPresenter(chain *entity.EvolutionChain, l10n *localizer) presenter.EvolutionChain {
+    children := make(map[uint][]entity.EvolutionChainLink)
+    var root *entity.EvolutionChainLink
+    for i, link := range chain.Links {
+        if link.EvolvesFromSpeciesID == nil {
+            if root == nil {
+                root = &chain.Links[i]
+            }
+            continue
+        }
+        children[*link.EvolvesFromSpeciesID] = append(children[*link.EvolvesFromSpeciesID], link)
+    }
+
+    var build func(link entity.EvolutionChainLink) presenter.EvolutionNode
+    build = func(link entity.EvolutionChainLink) presenter.EvolutionNode {
+        details := make([]presenter.EvolutionDetail, len(link.Details))
+        for i, detail := range link.Details {
+            details[i] = presenter.EvolutionDetail(detail)
+        }
+
+        evolvesTo := make([]presenter.EvolutionNode, 0, len(children[link.SpeciesID]))
+        for _, child := range children[link.SpeciesID] {
+            evolvesTo = append(evolvesTo, build(child))
+        }
+
+        displayName := link.SpeciesName
+        if link.Species != nil {
+            translation, ok := l10n.translate(link.Species)
+            displayName = localizedName(translation, ok, link.SpeciesName)
+        }
+
+        return presenter.EvolutionNode{
+            SpeciesID:        link.SpeciesID,
+            Name:             link.SpeciesName,
+            DisplayName:      displayName,
+            IsBaby:           link.IsBaby,
+            EvolutionDetails: details,
+            EvolvesTo:        evolvesTo,
+        }
+    }
+
+    result := presenter.EvolutionChain{ID: chain.ID}
+    if root != nil {
+        result.Chain = build(*root)
+    }
+    return result
+}
+
+func toMovePresenter(move *entity.Move) presenter.Move {
+    return presenter.Move{
+        ID:          move.ID,
+        Name:        move.Name,
+        Power:       move.Power,
+        Accuracy:    move.Accuracy,
+        PP:          move.PP,
+        DamageClass: move.DamageClass,
+        Type:        move.TypeName,
+    }
+}
+
+func toAbilityPresenter(ability *entity.Ability, l10n *localizer) presenter.Ability {
+    translation, ok := l10n.translate(ability)
+    return presenter.Ability{
+        ID:          ability.ID,
+        Name:        ability.Name,
+        DisplayName: localizedName(translation, ok, ability.Name),
+        FlavorText:  translation.Description,
+        Effect:      ability.Effect,
+        ShortEffect: ability.ShortEffect,
+        Generation:  ability.Generation,
+    }
+}
+
+func toTypeMatchupsPresenter(pokemonType *entity.Type, relations []*entity.TypeDamageRelation, l10n *localizer) presenter.TypeMatchups {
+    matchups := presenter.TypeMatchups{
+        Type:       toTypePresenter(pokemonType, l10n),
+        DamageTo:   newDamageRelationsPresenter(),
+        DamageFrom: newDamageRelationsPresenter(),
+    }
+    for _, relation := range relations {
+        // A type can appear on both sides, e.g. dragon against dragon
+        if relation.AttackingTypeID == pokemonType.ID {
+            addDamageRelation(&matchups.DamageTo, toTypePresenter(&relation.DefendingType, l10n), relation.Multiplier)
+        }
+        if relation.DefendingTypeID == pokemonType.ID {
+            addDamageRelation(&matchups.DamageFrom, toTypePresenter(&relation.AttackingType, l10n), relation.Multiplier)
+        }
+    }
+    return matchups
+}
+
+func newDamageRelationsPresenter() presenter.DamageRelations {
+    return presenter.DamageRelations{
+        DoubleDamage: []presenter.Type{},
+        HalfDamage:   []presenter.Type{},
+        NoDamage:     []presenter.Type{},
+    }
+}
+
+func toTypePresenter(pokemonType *entity.Type, l10n *localizer) presenter.Type {
+    translation, ok := l10n.translate(pokemonType)
+    return presenter.Type{
+        ID:          pokemonType.ID,
+        Name:        pokemonType.Name,
+        DisplayName: localizedName(translation, ok, pokemonType.Name),
+    }
+}
+
+func addDamageRelation(relations *presenter.DamageRelations, item presenter.Type, multiplier float64) {
+    switch multiplier {
+    case entity.DamageMultiplierDouble:
+        relations.DoubleDamage = append(relations.DoubleDamage, item)
+    case entity.DamageMultiplierHalf:
+        relations.HalfDamage = append(relations.HalfDamage, item)
+    case entity.DamageMultiplierNone:
+        relations.NoDamage = append(relations.NoDamage, item)
+    }
+}
+
+func toMatchupPresenter(matchup *entity.Matchup) presenter.Matchup {
+    result := presenter.Matchup{
+        AttackingType:  matchup.AttackingType,
+        DefendingTypes: make([]presenter.TypeMultiplier, len(matchup.DefendingTypes)),
+        Multiplier:     matchup.Multiplier,
+    }
+    if matchup.DefendingPokemon != nil {
+        result.DefendingPokemon = &presenter.PokemonSummary{
+            ID:   matchup.DefendingPokemon.ID,
+            Name: matchup.DefendingPokemon.Name,
+        }
+    }
+    for i, defender := range matchup.DefendingTypes {
+        result.DefendingTypes[i] = presenter.TypeMultiplier{
+            Type:       defender.Type,
+            Multiplier: defender.Multiplier,
+        }
+    }
+    return result
+}
+
+func toSyncRunPresenter(run *entity.SyncRun) presenter.SyncRun {
+    return presenter.SyncRun{
+        ID:           run.ID,
+        TriggeredBy:  string(run.TriggeredBy),
+        Status:       string(run.Status),
+        StartedAt:    run.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+        FinishedAt:   formatOptionalTime(run.FinishedAt),
+        SuccessCount: run.SuccessCount,
+        SkipCount:    run.SkipCount,
+        ErrorCount:   run.ErrorCount,
+        Error:        run.Error,
+        Failures:     toSyncFailurePresenters(run.Failures),
+    }
+}
+
+func toSyncJobPresenter(job *entity.SyncJob) presenter.SyncJob {
+    return presenter.SyncJob{
+        ID:           job.ID,
+        RunID:        job.RunID,
+        TriggeredBy:  string(job.TriggeredBy),
+        State:        string(job.State),
+        Processed:    job.Processed,
+        Total:        job.Total,
+        SuccessCount: job.SuccessCount,
+        SkipCount:    job.SkipCount,
+        ErrorCount:   job.ErrorCount,
+        Error:        job.Error,
+        Failures:     toSyncFailurePresenters(job.Failures),
+        StartedAt:    job.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+        FinishedAt:   formatOptionalTime(job.FinishedAt),
+    }
+}
+
+func toSyncDryRunPresenter(report *entity.SyncDryRun) presenter.SyncDryRun {
+    changes := make([]presenter.PokemonChange, len(report.Changes))
+    for i, change := range report.Changes {
+        changes[i] = presenter.PokemonChange{
+            PokemonID: change.PokemonID,
+            Name:      change.Name,
+            Action:    string(change.Action),
+            Diff:      toPokemonDiffPresenter(change.Diff),
+        }
+    }
+
+    return presenter.SyncDryRun{
+        Total:          report.Total,
+        CreateCount:    report.CreateCount,
+        UpdateCount:    report.UpdateCount,
+        UnchangedCount: report.UnchangedCount,
+        ErrorCount:     report.ErrorCount,
+        Changes:        changes,
+        Failures:       toSyncFailurePresenters(report.Failures),
+    }
+}
+
+func toPokemonDiffPresenter(diff entity.PokemonDiff) presenter.PokemonDiff {
+    return presenter.PokemonDiff{
+        Fields:           toFieldChangePresenters(diff.Fields),
+        AddedTypes:       nonNilStrings(diff.AddedTypes),
+        RemovedTypes:     nonNilStrings(diff.RemovedTypes),
+        AddedAbilities:   nonNilStrings(diff.AddedAbilities),
+        RemovedAbilities: nonNilStrings(diff.RemovedAbilities),
+        Stats:            toFieldChangePresenters(diff.Stats),
+        AddedMoves:       nonNilStrings(diff.AddedMoves),
+        RemovedMoves:     nonNilStrings(diff.RemovedMoves),
+    }
+}
+
+func toPokemonHistoryPresenter(entry *entity.PokemonHistory) presenter.PokemonHistoryEntry {
+    return presenter.PokemonHistoryEntry{
+        Version:   entry.Version,
+        SyncRunID: entry.SyncRunID,
+        Previous:  toPokemonSnapshotPresenter(entry.Previous),
+        Current:   toPokemonSnapshotPresenter(entry.Current),
+        Diff:      toPokemonDiffPresenter(entry.Diff),
+        CreatedAt: entry.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+    }
+}
+
+func toPokemonSnapshotPresenter(snapshot entity.PokemonSnapshot) presenter.PokemonSnapshot {
+    sprites := make(map[string]string, len(entity.SpriteVariants))
+    for _, variant := range entity.SpriteVariants {
+        sprites[variant], _ = snapshot.Sprites.URL(variant)
+    }
+
+    stats := make([]presenter.PokemonStat, len(snapshot.Stats))
+    for i, stat := range snapshot.Stats {
+        stats[i] = presenter.PokemonStat{
+            Name:     stat.Name,
+            BaseStat: stat.BaseStat,
+            Effort:   stat.Effort,
+        }
+    }
+
+    return presenter.PokemonSnapshot{
+        Name:      snapshot.Name,
+        Height:    snapshot.Height,
+        Weight:    snapshot.Weight,
+        BaseExp:   snapshot.BaseExp,
+        Order:     snapshot.Order,
+        SpeciesID: snapshot.SpeciesID,
+        IsDefault: snapshot.IsDefault,
+        FormName:  snapshot.FormName,
+        Sprites:   sprites,
+        Types:     nonNilStrings(snapshot.Types),
+        Abilities: nonNilStrings(snapshot.Abilities),
+        Stats:     stats,
+    }
+}
+
+func toFieldChangePresenters(changes []entity.FieldChange) []presenter.FieldChange {
+    result := make([]presenter.FieldChange, len(changes))
+    for i, change := range changes {
+        result[i] = presenter.FieldChange{
+            Field: change.Field,
+            Old:   change.Old,
+            New:   change.New,
+        }
+    }
+    return result
+}
+
+func parsePokemonMoveFilter(c *gin.Context) repository.PokemonMoveFilter {
+    return repository.PokemonMoveFilter{
+        LearnMethod:  strings.ToLower(strings.TrimSpace(c.Query("learn_method"))),
+        VersionGroup: strings.ToLower(strings.TrimSpace(c.Query("version_group"))),
+    }
+}
+
+// nonNilStrings makes empty lists render as [] rather than null.
+func nonNilStrings(values []string) []string {
+    if values == nil {
+        return []string{}
+    }
+    return values
+}
+
+func toSyncFailurePresenters(failures []entity.SyncFailure) []presenter.SyncFailure {
+    result := make([]presenter.SyncFailure, len(failures))
+    for i, failure := range failures {
+        result[i] = presenter.SyncFailure{
+            PokemonID: failure.PokemonID,
+            Reason:    failure.Reason,
+        }
+    }
+    return result
+}
+
+func formatOptionalTime(t *time.Time) *string {
+    if t == nil {
+        return nil
+    }
+    formatted := t.Format("2006-01-02T15:04:05Z07:00")
+    return &formatted
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+    "bytes"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "strings"
+
+    "github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+var (
+    errInvalidCursor      = errors.New("invalid cursor")
+    errCursorListMismatch = errors.New("cursor was issued for a different list")
+    errCursorSortMismatch = errors.New("cursor was issued for a different sort")
+)
+
+// Lists a cursor can be issued for.
+const (
+    cursorListItems       = "items"
+    cursorListAbilities   = "abilities"
+    cursorListMovePokemon = "move_pokemon"
+)
+
+// cursorCodec turns keyset pagination cursors into opaque tokens and back.
+// Tokens are signed, so clients cannot forge or edit a position.
+type cursorCodec struct {
+    key []byte
+}
+
+func newCursorCodec(secret string) cursorCodec {
+    return cursorCodec{key: []byte(secret)}
+}
+
+// cursorPayload carries the list and sort a cursor was issued for, so that
+// it is not read as a position in another list or one sorted differently.
+type cursorPayload struct {
+    List   string        `json:"list"`
+    Sort   string        `json:"sort,omitempty"`
+    Values []interface{} `json:"values,omitempty"`
+    ID     uint          `json:"id"`
+}
+
+// encode returns the token of a position in the item list.
+func (cc cursorCodec) encode(cursor repository.PokemonCursor, order repository.PokemonSort) string {
+    return cc.seal(cursorPayload{List: cursorListItems, Sort: order.String(), Values: cursor.Values, ID: cursor.ID})
+}
+
+// decode verifies a token made by encode for the same sort and returns
+// its cursor.
+func (cc cursorCodec) decode(token string, order repository.PokemonSort) (repository.PokemonCursor, error) {
+    payload, err := cc.open(token, cursorListItems)
+    if err != nil {
+        return repository.PokemonCursor{}, err
+    }
+    if payload.Sort != order.String() || len(payload.Values) != len(order) {
+        return repository.PokemonCursor{}, errCursorSortMismatch
+    }
+    return repository.PokemonCursor{ID: payload.ID, Values: payload.Values}, nil
+}
+
+// encodeAbility returns the token of the position after the ability named
+// name in the ability list.
+func (cc cursorCodec) encodeAbility(name string) string {
+    return cc.seal(cursorPayload{List: cursorListAbilities, Values: []interface{}{name}})
+}
+
+// decodeAbility verifies a token made by encodeAbility and returns the
+// ability name in it.
+func (cc cursorCodec) decodeAbility(token string) (string, error) {
+    payload, err := cc.open(token, cursorListAbilities)
+    if err != nil {
+        return "", err
+    }
+    if len(payload.Values) != 1 {
+        return "", errInvalidCursor
+    }
+    name, ok := payload.Values[0].(string)
+    if !ok {
+        return "", errInvalidCursor
+    }
+    return name, nil
+}
+
+// encodeMovePokemon returns the token of the position after the Pokemon
+// with the given ID in a list of the Pokemon that learn a move.
+func (cc cursorCodec) encodeMovePokemon(id uint) string {
+    return cc.seal(cursorPayload{List: cursorListMovePokemon, ID: id})
+}
+
+// decodeMovePokemon verifies a token made by encodeMovePokemon and returns
+// the Pokemon ID in it.
+func (cc cursorCodec) decodeMovePokemon(token string) (uint, error) {
+    payload, err := cc.open(token, cursorListMovePokemon)
+    if err != nil {
+        return 0, err
+    }
+    return payload.ID, nil
+}
+
+// seal returns the token "<payload>.<signature>", both base64url encoded.
+func (cc cursorCodec) seal(cursor cursorPayload) string {
+    payload, _ := json.Marshal(cursor)
+    return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(cc.sign(payload))
+}
+
+// open verifies a token made by seal for list and returns its payload.
+func (cc cursorCodec) open(token, list string) (cursorPayload, error) {
+    encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+    if !ok {
+        return cursorPayload{}, errInvalidCursor
+    }
+    payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+    if err != nil {
+        return cursorPayload{}, errInvalidCursor
+    }
+    signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+    if err != nil || !hmac.Equal(signature, cc.sign(payload)) {
+        return cursorPayload{}, errInvalidCursor
+    }
+
+    var decoded cursorPayload
+    decoder := json.NewDecoder(bytes.NewReader(payload))
+    decoder.UseNumber()
+    if err := decoder.Decode(&decoded); err != nil {
+        return cursorPayload{}, errInvalidCursor
+    }
+    if decoded.List != list {
+        return cursorPayload{}, errCursorListMismatch
+    }
+
+    // Numbers come back as json.Number; sort values are whole numbers
+    // or strings
+    for i, value := range decoded.Values {
+        if number, ok := value.(json.Number); ok {
+            integer, err := number.Int64()
+            if err != nil {
+                return cursorPayload{}, errInvalidCursor
+            }
+            decoded.Values[i] = integer
+        }
+    }
+    return decoded, nil
+}
+
+func (cc cursorCodec) sign(payload []byte) []byte {
+    mac := hmac.New(sha256.New, cc.key)
+    mac.Write(payload)
+    return mac.Sum(nil)
+}
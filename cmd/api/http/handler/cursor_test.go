@@ -0,0 +1,123 @@
+package handler
+
+import (
+    "errors"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+    tests := []struct {
+        name   string
+        order  repository.PokemonSort
+        cursor repository.PokemonCursor
+        want   repository.PokemonCursor
+    }{
+        {
+            name:   "default sort",
+            cursor: repository.PokemonCursor{ID: 25},
+            want:   repository.PokemonCursor{ID: 25},
+        },
+        {
+            name:   "string value",
+            order:  repository.PokemonSort{{Name: "name"}},
+            cursor: repository.PokemonCursor{ID: 25, Values: []interface{}{"pikachu"}},
+            want:   repository.PokemonCursor{ID: 25, Values: []interface{}{"pikachu"}},
+        },
+        {
+            // Numbers of any Go type come back as int64, not float64
+            name:   "number values",
+            order:  repository.PokemonSort{{Name: "height", Desc: true}, {Name: "base_experience"}},
+            cursor: repository.PokemonCursor{ID: 25, Values: []interface{}{4, uint(112)}},
+            want:   repository.PokemonCursor{ID: 25, Values: []interface{}{int64(4), int64(112)}},
+        },
+        {
+            name:   "number too large for a float64",
+            order:  repository.PokemonSort{{Name: "order"}},
+            cursor: repository.PokemonCursor{ID: 25, Values: []interface{}{int64(1<<53 + 1)}},
+            want:   repository.PokemonCursor{ID: 25, Values: []interface{}{int64(1<<53 + 1)}},
+        },
+        {
+            name:   "mixed values",
+            order:  repository.PokemonSort{{Name: "weight"}, {Name: "name", Desc: true}},
+            cursor: repository.PokemonCursor{ID: 25, Values: []interface{}{60, "pikachu"}},
+            want:   repository.PokemonCursor{ID: 25, Values: []interface{}{int64(60), "pikachu"}},
+        },
+    }
+
+    cursors := newCursorCodec("secret")
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := cursors.decode(cursors.encode(tt.cursor, tt.order), tt.order)
+            if err != nil {
+                t.Fatalf("decode: %v", err)
+            }
+            if !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("decode = %#v, want %#v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestCursorCodecRejects(t *testing.T) {
+    byName := repository.PokemonSort{{Name: "name"}}
+    cursors := newCursorCodec("secret")
+    token := cursors.encode(repository.PokemonCursor{ID: 25, Values: []interface{}{"pikachu"}}, byName)
+    _, signature, _ := strings.Cut(token, ".")
+    edited, _, _ := strings.Cut(cursors.encode(repository.PokemonCursor{ID: 1, Values: []interface{}{"bulbasaur"}}, byName), ".")
+
+    tests := []struct {
+        name  string
+        token string
+        order repository.PokemonSort
+        want  error
+    }{
+        {
+            name:  "different sort",
+            token: token,
+            order: repository.PokemonSort{{Name: "name", Desc: true}},
+            want:  errCursorSortMismatch,
+        },
+        {
+            name:  "different list",
+            token: cursors.encodeAbility("static"),
+            order: byName,
+            want:  errCursorListMismatch,
+        },
+        {
+            name:  "other secret",
+            token: newCursorCodec("other").encode(repository.PokemonCursor{ID: 25, Values: []interface{}{"pikachu"}}, byName),
+            order: byName,
+            want:  errInvalidCursor,
+        },
+        {
+            name:  "edited payload",
+            token: edited + "." + signature,
+            order: byName,
+            want:  errInvalidCursor,
+        },
+        {
+            name:  "fractional number",
+            token: cursors.encode(repository.PokemonCursor{ID: 25, Values: []interface{}{0.5}}, repository.PokemonSort{{Name: "height"}}),
+            order: repository.PokemonSort{{Name: "height"}},
+            want:  errInvalidCursor,
+        },
+        {
+            name:  "not a token",
+            token: "pikachu",
+            order: byName,
+            want:  errInvalidCursor,
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := cursors.decode(tt.token, tt.order); !errors.Is(err, tt.want) {
+                t.Errorf("decode error = %v, want %v", err, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "github.com/AhmadNizar/cata-dtc/internal/repository"
+    "github.com/gin-gonic/gin"
+)
+
+// slugPattern matches upstream resource names such as "fire" or
+// "solar-power".
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// parsePokemonFilter reads the filters of the item list:
+//
+//   - include_forms=true lists forms along with the default varieties, and
+//     form=alola lists only the Pokemon of that form
+//   - type=fire,flying lists the Pokemon with any of the types, or all of
+//     them with type_match=all
+//   - ability=blaze and hidden_ability=true|false match abilities
+//   - min_ and max_ bounds on height, weight, base_experience and order
+//
+// It rejects malformed values rather than ignoring them.
+func parsePokemonFilter(c *gin.Context) (repository.PokemonFilter, error) {
+    var filter repository.PokemonFilter
+    var err error
+
+    if filter.Form, err = parseSlug(c, "form"); err != nil {
+        return filter, err
+    }
+    if raw := c.Query("include_forms"); raw != "" {
+        includeForms, err := strconv.ParseBool(raw)
+        if err != nil {
+            return filter, fmt.Errorf("invalid include_forms %q: must be true or false", raw)
+        }
+        filter.IncludeForms = includeForms
+    }
+
+    seen := make(map[string]bool)
+    for _, value := range c.QueryArray("type") {
+        if value == "" {
+            continue
+        }
+        for _, name := range strings.Split(value, ",") {
+            name = strings.ToLower(strings.TrimSpace(name))
+            if !slugPattern.MatchString(name) {
+                return filter, fmt.Errorf("invalid type %q", name)
+            }
+            if !seen[name] {
+                seen[name] = true
+                filter.Types = append(filter.Types, name)
+            }
+        }
+    }
+    switch match := c.Query("type_match"); match {
+    case "", "any":
+    case "all":
+        filter.AllTypes = true
+    default:
+        return filter, fmt.Errorf("invalid type_match %q: must be any or all", match)
+    }
+
+    if filter.Ability, err = parseSlug(c, "ability"); err != nil {
+        return filter, err
+    }
+    if raw := c.Query("hidden_ability"); raw != "" {
+        hidden, err := strconv.ParseBool(raw)
+        if err != nil {
+            return filter, fmt.Errorf("invalid hidden_ability %q: must be true or false", raw)
+        }
+        filter.HiddenAbility = &hidden
+    }
+
+    for _, field := range []struct {
+        name   string
+        bounds *repository.IntRange
+    }{
+        {"height", &filter.Height},
+        {"weight", &filter.Weight},
+        {"base_experience", &filter.BaseExp},
+        {"order", &filter.Order},
+    } {
+        if *field.bounds, err = parseIntRange(c, field.name); err != nil {
+            return filter, err
+        }
+    }
+
+    return filter, nil
+}
+
+// parseSlug reads an optional resource name from the query.
+func parseSlug(c *gin.Context, key string) (string, error) {
+    raw := c.Query(key)
+    if raw == "" {
+        return "", nil
+    }
+    value := strings.ToLower(strings.TrimSpace(raw))
+    if !slugPattern.MatchString(value) {
+        return "", fmt.Errorf("invalid %s %q", key, raw)
+    }
+    return value, nil
+}
+
+// parseIntRange reads the min_<name> and max_<name> bounds of a field.
+func parseIntRange(c *gin.Context, name string) (repository.IntRange, error) {
+    var bounds repository.IntRange
+    for _, bound := range []struct {
+        key   string
+        value **int
+    }{
+        {"min_" + name, &bounds.Min},
+        {"max_" + name, &bounds.Max},
+    } {
+        raw := c.Query(bound.key)
+        if raw == "" {
+            continue
+        }
+        value, err := strconv.Atoi(raw)
+        if err != nil || value < 0 {
+            return bounds, fmt.Errorf("invalid %s %q: must be a non-negative integer", bound.key, raw)
+        }
+        *bound.value = &value
+    }
+
+    if bounds.Min != nil && bounds.Max != nil && *bounds.Min > *bounds.Max {
+        return bounds, fmt.Errorf("min_%s cannot be greater than max_%s", name, name)
+    }
+    return bounds, nil
+}
+
+// parsePokemonSort reads sort=-base_experience,name: a comma separated
+// list of fields from repository.PokemonSortFields, each descending when
+// prefixed with "-". Ties are broken by id in the repository.
+func parsePokemonSort(c *gin.Context) (repository.PokemonSort, error) {
+    raw := c.Query("sort")
+    if raw == "" {
+        return nil, nil
+    }
+
+    var order repository.PokemonSort
+    seen := make(map[string]bool)
+    for _, part := range strings.Split(raw, ",") {
+        part = strings.TrimSpace(part)
+        field := repository.SortField{Name: strings.TrimPrefix(part, "-")}
+        field.Desc = field.Name != part
+        if !isPokemonSortField(field.Name) {
+            return nil, fmt.Errorf("invalid sort field %q: must be one of %s", field.Name, strings.Join(repository.PokemonSortFields, ", "))
+        }
+        if seen[field.Name] {
+            return nil, fmt.Errorf("sort field %q is repeated", field.Name)
+        }
+        seen[field.Name] = true
+        order = append(order, field)
+    }
+    return order, nil
+}
+
+func isPokemonSortField(name string) bool {
+    for _, field := range repository.PokemonSortFields {
+        if field == name {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/AhmadNizar/cata-dtc/internal/repository"
+    "github.com/gin-gonic/gin"
+)
+
+// newQueryContext returns a context for a request with the given query
+// string.
+func newQueryContext(query string) *gin.Context {
+    c, _ := gin.CreateTestContext(httptest.NewRecorder())
+    c.Request = httptest.NewRequest("GET", "/api/v1/items?"+query, nil)
+    return c
+}
+
+func intPtr(value int) *int {
+    return &value
+}
+
+func boolPtr(value bool) *bool {
+    return &value
+}
+
+func TestParsePokemonFilter(t *testing.T) {
+    tests := []struct {
+        name  string
+        query string
+        want  repository.PokemonFilter
+    }{
+        {
+            name:  "no filters",
+            query: "",
+            want:  repository.PokemonFilter{},
+        },
+        {
+            name:  "forms",
+            query: "include_forms=true&form=Alola",
+            want:  repository.PokemonFilter{IncludeForms: true, Form: "alola"},
+        },
+        {
+            name:  "types are lower cased and deduplicated across parameters",
+            query: "type=Fire,%20flying&type=fire",
+            want:  repository.PokemonFilter{Types: []string{"fire", "flying"}},
+        },
+        {
+            name:  "all types",
+            query: "type=fire,flying&type_match=all",
+            want:  repository.PokemonFilter{Types: []string{"fire", "flying"}, AllTypes: true},
+        },
+        {
+            name:  "hidden ability",
+            query: "ability=solar-power&hidden_ability=true",
+            want:  repository.PokemonFilter{Ability: "solar-power", HiddenAbility: boolPtr(true)},
+        },
+        {
+            name:  "not hidden ability",
+            query: "hidden_ability=false",
+            want:  repository.PokemonFilter{HiddenAbility: boolPtr(false)},
+        },
+        {
+            name:  "bounds",
+            query: "min_height=4&max_weight=60&min_base_experience=0&max_order=35",
+            want: repository.PokemonFilter{
+                Height:  repository.IntRange{Min: intPtr(4)},
+                Weight:  repository.IntRange{Max: intPtr(60)},
+                BaseExp: repository.IntRange{Min: intPtr(0)},
+                Order:   repository.IntRange{Max: intPtr(35)},
+            },
+        },
+        {
+            name:  "equal bounds",
+            query: "min_height=4&max_height=4",
+            want:  repository.PokemonFilter{Height: repository.IntRange{Min: intPtr(4), Max: intPtr(4)}},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := parsePokemonFilter(newQueryContext(tt.query))
+            if err != nil {
+                t.Fatalf("parsePokemonFilter: %v", err)
+            }
+            if !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("parsePokemonFilter = %+v, want %+v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestParsePokemonFilterRejects(t *testing.T) {
+    tests := []struct {
+        name  string
+        query string
+    }{
+        {"include_forms not a bool", "include_forms=maybe"},
+        {"form not a slug", "form=alola!"},
+        {"type not a slug", "type=fire,fly%20ing"},
+        {"empty type in list", "type=fire,"},
+        {"unknown type_match", "type_match=some"},
+        {"ability not a slug", "ability=solar_power"},
+        {"hidden_ability not a bool", "hidden_ability=yes"},
+        {"bound not a number", "min_height=tall"},
+        {"negative bound", "max_weight=-1"},
+        {"min above max", "min_order=10&max_order=5"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := parsePokemonFilter(newQueryContext(tt.query)); err == nil {
+                t.Errorf("parsePokemonFilter(%q) succeeded, want an error", tt.query)
+            }
+        })
+    }
+}
+
+func TestParsePokemonSort(t *testing.T) {
+    tests := []struct {
+        name  string
+        query string
+        want  repository.PokemonSort
+    }{
+        {
+            // The repository breaks ties by id, so no sort means by id
+            name:  "default",
+            query: "",
+            want:  nil,
+        },
+        {
+            name:  "ascending",
+            query: "sort=name",
+            want:  repository.PokemonSort{{Name: "name"}},
+        },
+        {
+            name:  "descending then ascending",
+            query: "sort=-base_experience,name",
+            want:  repository.PokemonSort{{Name: "base_experience", Desc: true}, {Name: "name"}},
+        },
+        {
+            name:  "explicit id",
+            query: "sort=height,-id",
+            want:  repository.PokemonSort{{Name: "height"}, {Name: "id", Desc: true}},
+        },
+        {
+            name:  "spaces around fields",
+            query: "sort=weight,%20-order",
+            want:  repository.PokemonSort{{Name: "weight"}, {Name: "order", Desc: true}},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            order, err := parsePokemonSort(newQueryContext(tt.query))
+            if err != nil {
+                t.Fatalf("parsePokemonSort: %v", err)
+            }
+            if !reflect.DeepEqual(order, tt.want) {
+                t.Errorf("parsePokemonSort = %+v, want %+v", order, tt.want)
+            }
+        })
+    }
+}
+
+func TestParsePokemonSortRejects(t *testing.T) {
+    tests := []struct {
+        name  string
+        query string
+    }{
+        {"unknown field", "sort=color"},
+        {"field of another list", "sort=power"},
+        {"repeated field", "sort=name,-name"},
+        {"empty field", "sort=name,"},
+        {"bare minus", "sort=-"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := parsePokemonSort(newQueryContext(tt.query)); err == nil {
+                t.Errorf("parsePokemonSort(%q) succeeded, want an error", tt.query)
+            }
+        })
+    }
+}
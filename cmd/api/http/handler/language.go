@@ -0,0 +1,148 @@
+package handler
+
+import (
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/AhmadNizar/cata-dtc/internal/entity"
+    "github.com/gin-gonic/gin"
+)
+
+// negotiateLanguage picks the language to serve from the lang query
+// parameter or, without one, the Accept-Language header, and returns a
+// localizer for it. It falls back to English when neither names a
+// supported language.
+func (ah *ApiHandler) negotiateLanguage(c *gin.Context) *localizer {
+    language := entity.DefaultLanguage
+    if lang := c.Query("lang"); lang != "" {
+        if supported, ok := ah.matchLanguage(lang); ok {
+            language = supported
+        }
+    } else {
+        for _, tag := range parseAcceptLanguage(c.GetHeader("Accept-Language")) {
+            if supported, ok := ah.matchLanguage(tag); ok {
+                language = supported
+                break
+            }
+        }
+    }
+
+    c.Header("Vary", "Accept-Language")
+    return newLocalizer(language)
+}
+
+// translatable is a resource with localized names, such as a type.
+type translatable interface {
+    Translate(language string) (entity.Translation, bool)
+}
+
+// localizer translates resources into a language and records the
+// languages it actually served. Those differ from the requested one when a
+// resource has no translation in it and falls back to English.
+type localizer struct {
+    language string
+    served   []string
+}
+
+func newLocalizer(language string) *localizer {
+    return &localizer{language: language}
+}
+
+// translate returns the translation of resource in the localizer's
+// language. A resource without any translation is shown by its slug, which
+// counts as English.
+func (l *localizer) translate(resource translatable) (entity.Translation, bool) {
+    translation, ok := resource.Translate(l.language)
+    served := entity.DefaultLanguage
+    if ok {
+        served = translation.Language
+    }
+    for _, language := range l.served {
+        if language == served {
+            return translation, ok
+        }
+    }
+    l.served = append(l.served, served)
+    return translation, ok
+}
+
+// contentLanguage reports the languages served so far in the
+// Content-Language header and returns them for the response body, the
+// requested one first: "ja", or "ja, en" when some names fell back to
+// English. It is empty when nothing was translated.
+func (l *localizer) contentLanguage(c *gin.Context) string {
+    sort.SliceStable(l.served, func(i, j int) bool {
+        return l.served[i] == l.language && l.served[j] != l.language
+    })
+    served := strings.Join(l.served, ", ")
+    if served != "" {
+        c.Header("Content-Language", served)
+    }
+    return served
+}
+
+// matchLanguage matches a language tag such as "ja-JP" against the
+// supported languages, exactly or by its primary subtag.
+func (ah *ApiHandler) matchLanguage(tag string) (string, bool) {
+    tag = strings.ToLower(strings.TrimSpace(tag))
+    primary, _, _ := strings.Cut(tag, "-")
+    for _, candidate := range []string{tag, primary} {
+        for _, supported := range ah.languages {
+            if strings.ToLower(supported) == candidate {
+                return supported, true
+            }
+        }
+    }
+    return "", false
+}
+
+// parseAcceptLanguage returns the tags of an Accept-Language header, most
+// preferred first. Tags with q=0 and the * wildcard are dropped.
+func parseAcceptLanguage(header string) []string {
+    type weightedTag struct {
+        tag     string
+        quality float64
+    }
+
+    var tags []weightedTag
+    for _, part := range strings.Split(header, ",") {
+        tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+        tag = strings.TrimSpace(tag)
+        if tag == "" || tag == "*" {
+            continue
+        }
+
+        quality := 1.0
+        if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+            parsed, err := strconv.ParseFloat(value, 64)
+            if err != nil {
+                continue
+            }
+            quality = parsed
+        }
+        if quality <= 0 {
+            continue
+        }
+        tags = append(tags, weightedTag{tag: tag, quality: quality})
+    }
+
+    sort.SliceStable(tags, func(i, j int) bool {
+        return tags[i].quality > tags[j].quality
+    })
+
+    result := make([]string, len(tags))
+    for i, t := range tags {
+        result[i] = t.tag
+    }
+    return result
+}
+
+// localizedName returns the name of a translation, or slug when the
+// resource has not been translated.
+func localizedName(translation entity.Translation, ok bool, slug string) string {
+    if !ok || translation.Name == "" {
+        return slug
+    }
+    return translation.Name
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+    tests := []struct {
+        name   string
+        header string
+        want   []string
+    }{
+        {"empty", "", []string{}},
+        {"single tag", "ja", []string{"ja"}},
+        {"ordered by quality", "en;q=0.5, ja-JP, ja;q=0.8", []string{"ja-JP", "ja", "en"}},
+        {"equal qualities keep their order", "fr;q=0.7, de;q=0.7", []string{"fr", "de"}},
+        {"zero quality is dropped", "ja;q=0, en", []string{"en"}},
+        {"wildcard is dropped", "*, ja;q=0.1", []string{"ja"}},
+        {"malformed quality is dropped", "ja;q=high, en", []string{"en"}},
+        {"blank parts are skipped", " , ja ,", []string{"ja"}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := parseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+                t.Errorf("parseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestMatchLanguage(t *testing.T) {
+    ah := &ApiHandler{languages: []string{"en", "ja", "zh-Hans"}}
+    tests := []struct {
+        tag    string
+        want   string
+        wantOK bool
+    }{
+        {"ja", "ja", true},
+        {"JA", "ja", true},
+        {"ja-JP", "ja", true},
+        {"zh-hans", "zh-Hans", true},
+        {"zh-Hans-CN", "", false},
+        {"fr", "", false},
+    }
+
+    for _, tt := range tests {
+        got, ok := ah.matchLanguage(tt.tag)
+        if got != tt.want || ok != tt.wantOK {
+            t.Errorf("matchLanguage(%q) = (%q, %v), want (%q, %v)", tt.tag, got, ok, tt.want, tt.wantOK)
+        }
+    }
+}
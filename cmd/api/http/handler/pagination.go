@@ -0,0 +1,91 @@
+package handler
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+
+    "github.com/AhmadNizar/cata-dtc/internal/presenter"
+    "github.com/gin-gonic/gin"
+)
+
+const (
+    defaultPageLimit = 20
+    maxPageLimit     = 100
+)
+
+// parsePageParams reads the page and limit query parameters of every
+// paginated endpoint, defaulting to the first page of defaultPageLimit
+// items. It rejects values that are not numbers or out of bounds rather
+// than replacing them.
+func parsePageParams(c *gin.Context) (int, int, error) {
+    page, limit := 1, defaultPageLimit
+
+    if raw := c.Query("page"); raw != "" {
+        value, err := strconv.Atoi(raw)
+        if err != nil || value < 1 {
+            return 0, 0, fmt.Errorf("invalid page %q: must be a positive integer", raw)
+        }
+        page = value
+    }
+
+    if raw := c.Query("limit"); raw != "" {
+        value, err := strconv.Atoi(raw)
+        if err != nil || value < 1 || value > maxPageLimit {
+            return 0, 0, fmt.Errorf("invalid limit %q: must be between 1 and %d", raw, maxPageLimit)
+        }
+        limit = value
+    }
+
+    return page, limit, nil
+}
+
+// cursorParam reads the cursor query parameter, which switches a list
+// from offset to keyset pagination. A cursor already holds the position,
+// so it cannot be combined with page.
+func cursorParam(c *gin.Context) (string, error) {
+    token := c.Query("cursor")
+    if token != "" && c.Query("page") != "" {
+        return "", errors.New("cursor and page cannot be used together")
+    }
+    return token, nil
+}
+
+// totalPages is the number of pages of limit items it takes to hold total.
+func totalPages(total int64, limit int) int {
+    return int((total + int64(limit) - 1) / int64(limit))
+}
+
+// cursorLinks builds the links of a page reached by cursor. There is no
+// link back, as a cursor only moves forward.
+func cursorLinks(c *gin.Context, nextCursor *string) presenter.PageLinks {
+    links := presenter.PageLinks{Self: c.Request.URL.RequestURI()}
+    if nextCursor != nil {
+        query := c.Request.URL.Query()
+        query.Set("cursor", *nextCursor)
+        next := c.Request.URL.Path + "?" + query.Encode()
+        links.Next = &next
+    }
+    return links
+}
+
+// pageLinks builds the links of a page from the request URL, keeping its
+// other query parameters.
+func pageLinks(c *gin.Context, page, pages int) presenter.PageLinks {
+    link := func(page int) string {
+        query := c.Request.URL.Query()
+        query.Set("page", strconv.Itoa(page))
+        return c.Request.URL.Path + "?" + query.Encode()
+    }
+
+    links := presenter.PageLinks{Self: link(page)}
+    if page < pages {
+        next := link(page + 1)
+        links.Next = &next
+    }
+    if page > 1 {
+        prev := link(min(page-1, max(pages, 1)))
+        links.Prev = &prev
+    }
+    return links
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+    "testing"
+)
+
+func TestParsePageParams(t *testing.T) {
+    tests := []struct {
+        name      string
+        query     string
+        wantPage  int
+        wantLimit int
+    }{
+        {"defaults", "", 1, defaultPageLimit},
+        {"page only", "page=3", 3, defaultPageLimit},
+        {"limit only", "limit=5", 1, 5},
+        {"largest limit", "limit=100", 1, maxPageLimit},
+        {"both", "page=2&limit=50", 2, 50},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            page, limit, err := parsePageParams(newQueryContext(tt.query))
+            if err != nil {
+                t.Fatalf("parsePageParams: %v", err)
+            }
+            if page != tt.wantPage || limit != tt.wantLimit {
+                t.Errorf("parsePageParams = (%d, %d), want (%d, %d)", page, limit, tt.wantPage, tt.wantLimit)
+            }
+        })
+    }
+}
+
+func TestParsePageParamsRejects(t *testing.T) {
+    tests := []struct {
+        name  string
+        query string
+    }{
+        {"page zero", "page=0"},
+        {"negative page", "page=-1"},
+        {"page not a number", "page=two"},
+        {"limit zero", "limit=0"},
+        {"limit above max", "limit=101"},
+        {"limit not a number", "limit=ten"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, _, err := parsePageParams(newQueryContext(tt.query)); err == nil {
+                t.Errorf("parsePageParams(%q) succeeded, want an error", tt.query)
+            }
+        })
+    }
+}
+
+func TestCursorParam(t *testing.T) {
+    tests := []struct {
+        name    string
+        query   string
+        want    string
+        wantErr bool
+    }{
+        {name: "no cursor", query: "page=2", want: ""},
+        {name: "cursor", query: "cursor=abc.def&limit=5", want: "abc.def"},
+        {name: "cursor with page", query: "cursor=abc.def&page=2", wantErr: true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := cursorParam(newQueryContext(tt.query))
+            if (err != nil) != tt.wantErr {
+                t.Fatalf("cursorParam error = %v, want error %v", err, tt.wantErr)
+            }
+            if got != tt.want {
+                t.Errorf("cursorParam = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestTotalPages(t *testing.T) {
+    tests := []struct {
+        total int64
+        limit int
+        want  int
+    }{
+        {0, 20, 0},
+        {1, 20, 1},
+        {20, 20, 1},
+        {21, 20, 2},
+    }
+
+    for _, tt := range tests {
+        if got := totalPages(tt.total, tt.limit); got != tt.want {
+            t.Errorf("totalPages(%d, %d) = %d, want %d", tt.total, tt.limit, got, tt.want)
+        }
+    }
+}
@@ -11,7 +11,27 @@ func NewRouter(apiHandler *handler.ApiHandler) *gin.Engine {
     v1 := router.Group("/api/v1")
 
     v1.POST("/sync", apiHandler.Sync)
+    v1.POST("/sync/dry-run", apiHandler.DryRunSync)
+    v1.POST("/sync/pokemon/:idOrName", apiHandler.SyncPokemon)
+    v1.GET("/sync/jobs/:id", apiHandler.GetSyncJob)
+    v1.GET("/sync/runs", apiHandler.GetSyncRuns)
+    v1.GET("/sync/runs/:id", apiHandler.GetSyncRun)
     v1.GET("/items", apiHandler.GetItems)
+    v1.GET("/items/:id", apiHandler.GetItem)
+    v1.GET("/items/name/:name", apiHandler.GetItemByName)
+    v1.GET("/items/:id/sprite/:variant", apiHandler.GetItemSprite)
+    v1.GET("/items/:id/evolutions", apiHandler.GetItemEvolutions)
+    v1.GET("/items/:id/moves", apiHandler.GetItemMoves)
+    v1.GET("/items/:id/history", apiHandler.GetItemHistory)
+    v1.GET("/moves/:name/pokemon", apiHandler.GetMovePokemon)
+    v1.GET("/abilities", apiHandler.GetAbilities)
+    v1.GET("/abilities/:name", apiHandler.GetAbility)
+    v1.GET("/types/:name/matchups", apiHandler.GetTypeMatchups)
+    v1.POST("/matchups", apiHandler.CalculateMatchup)
+
+    admin := v1.Group("/admin")
+    admin.GET("/pokemon/deleted", apiHandler.GetTombstonedPokemon)
+    admin.POST("/pokemon/:id/restore", apiHandler.RestorePokemon)
 
     v1.GET("/health", func(c *gin.Context) {
         c.JSON(200, gin.H{"status": "ok"})
@@ -0,0 +1,32 @@
+package api
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+
+    "github.com/AhmadNizar/cata-dtc/internal/config"
+    "github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// RunSync performs a single sync from the command line and exits. With
+// dryRun set, nothing is written and the diff is printed as JSON instead.
+func RunSync(cfg *config.Config, dryRun bool, pokemonIDs []int) error {
+    pokemonUseCase := newPokemonService(cfg)
+
+    if !dryRun {
+        if len(pokemonIDs) > 0 {
+            return fmt.Errorf("--ids is only supported together with --dry-run")
+        }
+        return pokemonUseCase.SyncPokemonData(entity.SyncTriggerCLI)
+    }
+
+    report, err := pokemonUseCase.DryRunSync(pokemonIDs)
+    if err != nil {
+        return err
+    }
+
+    encoder := json.NewEncoder(os.Stdout)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(report)
+}
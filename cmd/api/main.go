@@ -74,6 +74,12 @@ func action(c *cli.Context) {
 	api.Start(cfg)
 }
 
+func syncAction(c *cli.Context) error {
+	cfg := config.LoadConfig()
+
+	return api.RunSync(cfg, c.Bool("dry-run"), c.IntSlice("ids"))
+}
+
 func main() {
 	gotenv.OverLoad("/workspace/.env")
 
@@ -83,6 +89,23 @@ func main() {
 	app.Version = "1.0.0"
 	app.Flags = flags
 	app.Action = action
+	app.Commands = []cli.Command{
+		{
+			Name:  "sync",
+			Usage: "sync Pokemon data from upstream once and exit",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "print what would change as JSON without writing anything",
+				},
+				cli.IntSliceFlag{
+					Name:  "ids",
+					Usage: "Pokemon ID to preview, repeatable (dry run only)",
+				},
+			},
+			Action: syncAction,
+		},
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
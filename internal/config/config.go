@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,6 +20,17 @@ type AppConfig struct {
 	Host    string
 	Port    string
 	Env     string
+
+	// Languages are the upstream language codes the read endpoints serve,
+	// e.g. "ja". Anything else is served in English. Indonesian is not in
+	// the defaults because PokeAPI has no Indonesian names or flavor text,
+	// so "id" would only ever fall back to English.
+	Languages []string
+
+	// CursorSecret signs pagination cursors. It is required: the API
+	// refuses to start without it. Every instance must share it, so that a
+	// cursor issued by one is accepted by all of them.
+	CursorSecret string
 }
 
 type DatabaseConfig struct {
@@ -41,6 +53,24 @@ type PokemonConfig struct {
 	Timeout    time.Duration
 	MaxRetries int
 	CacheTTL   time.Duration
+
+	// Sync range and allow-list. SyncEndID of 0 means there is no upper
+	// bound, and an empty SyncIDs means every Pokemon in range is synced.
+	SyncStartID  int
+	SyncEndID    int
+	SyncPageSize int
+	SyncIDs      []int
+
+	// SyncConcurrency is the number of sync workers, and RateLimit caps
+	// upstream requests per second across all of them (0 disables it).
+	SyncConcurrency int
+	RateLimit       int
+
+	// SyncLockTTL is the lease on the cluster-wide sync lock.
+	SyncLockTTL time.Duration
+
+	// SpriteDir is where downloaded sprite images are stored.
+	SpriteDir string
 }
 
 func LoadConfig() *Config {
@@ -51,6 +81,9 @@ func LoadConfig() *Config {
 			Host:    getEnv("APP_HOST", "localhost"),
 			Port:    getEnv("APP_PORT", "8080"),
 			Env:     getEnv("APP_ENV", "development"),
+
+			Languages:    getEnvAsStringSlice("APP_LANGUAGES", "en,ja"),
+			CursorSecret: getEnv("APP_CURSOR_SECRET", ""),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("MYSQL_HOST", "mysql"),
@@ -70,6 +103,18 @@ func LoadConfig() *Config {
 			Timeout:    getEnvAsDuration("POKEMON_API_TIMEOUT", "30s"),
 			MaxRetries: getEnvAsInt("POKEMON_API_MAX_RETRIES", 3),
 			CacheTTL:   getEnvAsDuration("POKEMON_CACHE_TTL", "5m"),
+
+			SyncStartID:  getEnvAsInt("POKEMON_SYNC_START_ID", 1),
+			SyncEndID:    getEnvAsInt("POKEMON_SYNC_END_ID", 0),
+			SyncPageSize: getEnvAsInt("POKEMON_SYNC_PAGE_SIZE", 100),
+			SyncIDs:      getEnvAsIntSlice("POKEMON_SYNC_IDS"),
+
+			SyncConcurrency: getEnvAsInt("POKEMON_SYNC_CONCURRENCY", 5),
+			RateLimit:       getEnvAsInt("POKEMON_API_RATE_LIMIT", 10),
+
+			SyncLockTTL: getEnvAsDuration("POKEMON_SYNC_LOCK_TTL", "30s"),
+
+			SpriteDir: getEnv("POKEMON_SPRITE_DIR", "./data/sprites"),
 		},
 	}
 }
@@ -90,6 +135,31 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsIntSlice(key string) []int {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		if intValue, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			result = append(result, intValue)
+		}
+	}
+	return result
+}
+
+func getEnvAsStringSlice(key, defaultValue string) []string {
+	var result []string
+	for _, part := range strings.Split(getEnv(key, defaultValue), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,9 @@
+package dto
+
+// MatchupRequestDTO names an attacking type and a defender: either a stored
+// Pokemon, by ID or name, or one or two types.
+type MatchupRequestDTO struct {
+	AttackingType    string   `json:"attacking_type" binding:"required"`
+	DefendingPokemon string   `json:"defending_pokemon"`
+	DefendingTypes   []string `json:"defending_types" binding:"omitempty,min=1,max=2,dive,required"`
+}
@@ -1,9 +1,12 @@
 package dto
 
 type GeneralResponseDTO struct {
-	OK      bool        `json:"ok"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+	// Language lists the languages localized names in Data are served in,
+	// e.g. "ja", or "ja, en" when some names fell back to English.
+	Language string      `json:"language,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
 }
 
 type PokemonTypeDTO struct {
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+)
+
+// Ability is an entry in the abilities master table, shared by every
+// Pokemon that can have it. The details are empty until the ability has
+// been synced.
+type Ability struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"uniqueIndex:idx_ability_name;size:100;not null"`
+	Effect      string    `json:"effect" gorm:"type:text;not null"`
+	ShortEffect string    `json:"short_effect" gorm:"type:text;not null"`
+	Generation  string    `json:"generation" gorm:"size:50;not null;default:''"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Translations []AbilityTranslation `json:"translations,omitempty" gorm:"foreignKey:AbilityID"`
+}
+
+// Translate returns the localized name and flavor text of the ability in
+// language, falling back to DefaultLanguage.
+func (a *Ability) Translate(language string) (Translation, bool) {
+	return translate(a.Translations, language)
+}
+
+func (Ability) TableName() string {
+	return "ability"
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+)
+
+// EvolutionChain is an upstream evolution chain, stored as a flat list of
+// links that each point at the species they evolve from.
+type EvolutionChain struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Links []EvolutionChainLink `json:"links" gorm:"foreignKey:ChainID"`
+}
+
+func (EvolutionChain) TableName() string {
+	return "evolution_chain"
+}
+
+// EvolutionChainLink is one species in a chain. The root of the chain has
+// no EvolvesFromSpeciesID. Species are referenced by ID and name only, as
+// a chain may include species that have not been synced.
+type EvolutionChainLink struct {
+	ID                   uint              `json:"id" gorm:"primaryKey"`
+	ChainID              uint              `json:"chain_id" gorm:"not null;uniqueIndex:idx_evolution_chain_link_chain_id_species_id"`
+	SpeciesID            uint              `json:"species_id" gorm:"not null;uniqueIndex:idx_evolution_chain_link_chain_id_species_id"`
+	SpeciesName          string            `json:"species_name" gorm:"size:255;not null"`
+	EvolvesFromSpeciesID *uint             `json:"evolves_from_species_id"`
+	IsBaby               bool              `json:"is_baby" gorm:"default:false"`
+	Details              []EvolutionDetail `json:"details" gorm:"type:json;serializer:json"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+
+	// Species is only loaded for reads, and is nil when the species has
+	// not been synced.
+	Species *PokemonSpecies `json:"species,omitempty" gorm:"foreignKey:SpeciesID"`
+}
+
+func (EvolutionChainLink) TableName() string {
+	return "evolution_chain_link"
+}
+
+// EvolutionDetail is one way of evolving into a link's species: a trigger
+// such as "level-up" or "use-item" and the conditions that must hold. Unset
+// conditions are left empty.
+type EvolutionDetail struct {
+	Trigger               string `json:"trigger"`
+	MinLevel              *int   `json:"min_level,omitempty"`
+	Item                  string `json:"item,omitempty"`
+	HeldItem              string `json:"held_item,omitempty"`
+	MinHappiness          *int   `json:"min_happiness,omitempty"`
+	MinAffection          *int   `json:"min_affection,omitempty"`
+	MinBeauty             *int   `json:"min_beauty,omitempty"`
+	TimeOfDay             string `json:"time_of_day,omitempty"`
+	Gender                *int   `json:"gender,omitempty"`
+	KnownMove             string `json:"known_move,omitempty"`
+	KnownMoveType         string `json:"known_move_type,omitempty"`
+	Location              string `json:"location,omitempty"`
+	PartySpecies          string `json:"party_species,omitempty"`
+	PartyType             string `json:"party_type,omitempty"`
+	TradeSpecies          string `json:"trade_species,omitempty"`
+	RelativePhysicalStats *int   `json:"relative_physical_stats,omitempty"`
+	NeedsOverworldRain    bool   `json:"needs_overworld_rain,omitempty"`
+	TurnUpsideDown        bool   `json:"turn_upside_down,omitempty"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+// TypeMultiplier is the damage multiplier against one defending type.
+type TypeMultiplier struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// Matchup is the damage multiplier of an attacking type against a defender
+// with one or two types: the product of the multipliers against each.
+type Matchup struct {
+	AttackingType    string           `json:"attacking_type"`
+	DefendingPokemon *Pokemon         `json:"defending_pokemon,omitempty"`
+	DefendingTypes   []TypeMultiplier `json:"defending_types"`
+	Multiplier       float64          `json:"multiplier"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+)
+
+// Move is an entry in the moves catalogue. IDs are the upstream move IDs.
+// Power, Accuracy and PP are nil for moves that have none, such as status
+// moves that never miss.
+type Move struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	Name        string    `json:"name" gorm:"uniqueIndex:idx_move_name;size:255;not null"`
+	Power       *int      `json:"power"`
+	Accuracy    *int      `json:"accuracy"`
+	PP          *int      `json:"pp" gorm:"column:pp"`
+	DamageClass string    `json:"damage_class" gorm:"size:20"`
+	TypeName    string    `json:"type_name" gorm:"size:100"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (Move) TableName() string {
+	return "move"
+}
@@ -2,8 +2,13 @@ package entity
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// Pokemon is one variety of a species. Regional, mega and gigantamax
+// forms are stored as Pokemon of their own, with IsDefault unset and the
+// part of their name after the species name, e.g. "alola", in FormName.
 type Pokemon struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
 	Name      string    `json:"name" gorm:"uniqueIndex:idx_pokemon_name;size:255;not null"`
@@ -11,14 +16,38 @@ type Pokemon struct {
 	Weight    int       `json:"weight" gorm:"default:0"`
 	BaseExp   int       `json:"base_experience" gorm:"column:base_experience;default:0"`
 	OrderNum  int       `json:"order_num" gorm:"column:order_num;default:0"`
+	SpeciesID *uint     `json:"species_id"`
+	IsDefault bool      `json:"is_default" gorm:"not null;index:idx_pokemon_is_default"`
+	FormName  string    `json:"form_name" gorm:"size:100;not null;index:idx_pokemon_form_name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt tombstones a Pokemon that is gone from the upstream
+	// listing. Tombstoned Pokemon are hidden from reads.
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Upstream image URLs, stored in the sprite_* columns
+	Sprites PokemonSprites `json:"sprites" gorm:"embedded;embeddedPrefix:sprite_"`
 
 	// Relationships
 	Types     []PokemonType    `json:"types" gorm:"foreignKey:PokemonID"`
 	Abilities []PokemonAbility `json:"abilities" gorm:"foreignKey:PokemonID"`
+	Stats     []PokemonStat    `json:"stats" gorm:"foreignKey:PokemonID"`
+	// Moves is only loaded where it is needed, as a learnset can run to
+	// hundreds of rows.
+	Moves []PokemonMove `json:"moves,omitempty" gorm:"foreignKey:PokemonID"`
+	// Species is only loaded for reads that serve localized names.
+	Species *PokemonSpecies `json:"species,omitempty" gorm:"foreignKey:SpeciesID"`
 }
 
 func (Pokemon) TableName() string {
 	return "pokemon"
 }
+
+// UpsertResult reports what an idempotent create-or-update did with a record.
+type UpsertResult string
+
+const (
+	UpsertResultCreated   UpsertResult = "created"
+	UpsertResultUpdated   UpsertResult = "updated"
+	UpsertResultUnchanged UpsertResult = "unchanged"
+)
@@ -1,5 +1,35 @@
 package entity
 
+import (
+	"strconv"
+	"strings"
+)
+
+// NamedAPIResource is the upstream reference to another resource.
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ID extracts the numeric identifier from the resource URL, returning 0
+// when the URL does not end in one.
+func (r NamedAPIResource) ID() int {
+	segments := strings.Split(strings.TrimSuffix(r.URL, "/"), "/")
+	id, err := strconv.Atoi(segments[len(segments)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// PokemonListAPIResponse is a single page of the upstream /pokemon listing.
+type PokemonListAPIResponse struct {
+	Count    int                `json:"count"`
+	Next     string             `json:"next"`
+	Previous string             `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
 type PokemonTypeAPI struct {
 	Slot int `json:"slot"`
 	Type struct {
@@ -17,6 +47,12 @@ type PokemonAbilityAPI struct {
 	} `json:"ability"`
 }
 
+type PokemonStatAPI struct {
+	BaseStat int              `json:"base_stat"`
+	Effort   int              `json:"effort"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
 type PokemonSprites struct {
 	FrontDefault string `json:"front_default"`
 	BackDefault  string `json:"back_default"`
@@ -24,14 +60,152 @@ type PokemonSprites struct {
 	BackShiny    string `json:"back_shiny"`
 }
 
+// SpriteVariants lists the sprite variants that are stored and served.
+var SpriteVariants = []string{"front_default", "back_default", "front_shiny", "back_shiny"}
+
+// URL returns the upstream URL of a sprite variant. ok is false for a
+// variant that is not in SpriteVariants; url is empty when upstream has no
+// image for it.
+func (s PokemonSprites) URL(variant string) (url string, ok bool) {
+	switch variant {
+	case "front_default":
+		return s.FrontDefault, true
+	case "back_default":
+		return s.BackDefault, true
+	case "front_shiny":
+		return s.FrontShiny, true
+	case "back_shiny":
+		return s.BackShiny, true
+	}
+	return "", false
+}
+
 type PokemonAPIResponse struct {
-	ID              int              `json:"id"`
-	Name            string           `json:"name"`
-	Height          int              `json:"height"`
-	Weight          int              `json:"weight"`
-	BaseExperience  int              `json:"base_experience"`
-	Order           int              `json:"order"`
-	Types           []PokemonTypeAPI    `json:"types"`
-	Abilities       []PokemonAbilityAPI `json:"abilities"`
-	Sprites         PokemonSprites   `json:"sprites"`
-}
\ No newline at end of file
+	ID             int                 `json:"id"`
+	Name           string              `json:"name"`
+	Height         int                 `json:"height"`
+	Weight         int                 `json:"weight"`
+	BaseExperience int                 `json:"base_experience"`
+	Order          int                 `json:"order"`
+	IsDefault      bool                `json:"is_default"`
+	Types          []PokemonTypeAPI    `json:"types"`
+	Abilities      []PokemonAbilityAPI `json:"abilities"`
+	Stats          []PokemonStatAPI    `json:"stats"`
+	Sprites        PokemonSprites      `json:"sprites"`
+	Species        NamedAPIResource    `json:"species"`
+	Moves          []PokemonMoveAPI    `json:"moves"`
+}
+
+type PokemonMoveAPI struct {
+	Move                NamedAPIResource        `json:"move"`
+	VersionGroupDetails []MoveVersionDetailsAPI `json:"version_group_details"`
+}
+
+type MoveVersionDetailsAPI struct {
+	LevelLearnedAt  int              `json:"level_learned_at"`
+	MoveLearnMethod NamedAPIResource `json:"move_learn_method"`
+	VersionGroup    NamedAPIResource `json:"version_group"`
+}
+
+type MoveAPIResponse struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Power       *int             `json:"power"`
+	Accuracy    *int             `json:"accuracy"`
+	PP          *int             `json:"pp"`
+	DamageClass NamedAPIResource `json:"damage_class"`
+	Type        NamedAPIResource `json:"type"`
+}
+
+type PokemonSpeciesAPIResponse struct {
+	ID                 int               `json:"id"`
+	Name               string            `json:"name"`
+	EvolvesFromSpecies *NamedAPIResource `json:"evolves_from_species"`
+	EvolutionChain     NamedAPIResource  `json:"evolution_chain"`
+	Names              []NameAPI         `json:"names"`
+	FlavorTextEntries  []FlavorTextAPI   `json:"flavor_text_entries"`
+	Varieties          []VarietyAPI      `json:"varieties"`
+}
+
+// VarietyAPI is one of the Pokemon of a species.
+type VarietyAPI struct {
+	IsDefault bool             `json:"is_default"`
+	Pokemon   NamedAPIResource `json:"pokemon"`
+}
+
+// NameAPI is the name of a resource in one language.
+type NameAPI struct {
+	Name     string           `json:"name"`
+	Language NamedAPIResource `json:"language"`
+}
+
+// FlavorTextAPI is the description of a resource in one language, as shown
+// in one game version (species) or version group (abilities).
+type FlavorTextAPI struct {
+	FlavorText string           `json:"flavor_text"`
+	Language   NamedAPIResource `json:"language"`
+}
+
+type EvolutionChainAPIResponse struct {
+	ID    int          `json:"id"`
+	Chain ChainLinkAPI `json:"chain"`
+}
+
+type ChainLinkAPI struct {
+	IsBaby           bool                 `json:"is_baby"`
+	Species          NamedAPIResource     `json:"species"`
+	EvolutionDetails []EvolutionDetailAPI `json:"evolution_details"`
+	EvolvesTo        []ChainLinkAPI       `json:"evolves_to"`
+}
+
+type EvolutionDetailAPI struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	MinLevel              *int              `json:"min_level"`
+	Item                  *NamedAPIResource `json:"item"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	MinHappiness          *int              `json:"min_happiness"`
+	MinAffection          *int              `json:"min_affection"`
+	MinBeauty             *int              `json:"min_beauty"`
+	TimeOfDay             string            `json:"time_of_day"`
+	Gender                *int              `json:"gender"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+}
+
+type TypeAPIResponse struct {
+	ID              int                    `json:"id"`
+	Name            string                 `json:"name"`
+	DamageRelations TypeDamageRelationsAPI `json:"damage_relations"`
+	Names           []NameAPI              `json:"names"`
+}
+
+type TypeDamageRelationsAPI struct {
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+}
+
+type AbilityAPIResponse struct {
+	ID                int                     `json:"id"`
+	Name              string                  `json:"name"`
+	EffectEntries     []AbilityEffectEntryAPI `json:"effect_entries"`
+	Generation        NamedAPIResource        `json:"generation"`
+	Names             []NameAPI               `json:"names"`
+	FlavorTextEntries []FlavorTextAPI         `json:"flavor_text_entries"`
+}
+
+type AbilityEffectEntryAPI struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
@@ -0,0 +1,238 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FieldChange is a scalar field whose value differs between two versions of
+// a Pokemon.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// PokemonDiff lists what differs between a stored Pokemon and a newer
+// version of it.
+type PokemonDiff struct {
+	Fields           []FieldChange `json:"fields,omitempty"`
+	AddedTypes       []string      `json:"added_types,omitempty"`
+	RemovedTypes     []string      `json:"removed_types,omitempty"`
+	AddedAbilities   []string      `json:"added_abilities,omitempty"`
+	RemovedAbilities []string      `json:"removed_abilities,omitempty"`
+	Stats            []FieldChange `json:"stats,omitempty"`
+	AddedMoves       []string      `json:"added_moves,omitempty"`
+	RemovedMoves     []string      `json:"removed_moves,omitempty"`
+}
+
+// IsEmpty reports whether the two versions are the same.
+func (d PokemonDiff) IsEmpty() bool {
+	return len(d.Fields) == 0 &&
+		len(d.AddedTypes) == 0 && len(d.RemovedTypes) == 0 &&
+		len(d.AddedAbilities) == 0 && len(d.RemovedAbilities) == 0 &&
+		len(d.Stats) == 0 &&
+		len(d.AddedMoves) == 0 && len(d.RemovedMoves) == 0
+}
+
+// DiffPokemon compares the data that comes from upstream, ignoring IDs and
+// timestamps. It is the single definition of "changed" used when deciding
+// whether a Pokemon needs to be written.
+func DiffPokemon(existing, updated *Pokemon) PokemonDiff {
+	var diff PokemonDiff
+
+	// Compare basic fields
+	diff.Fields = appendFieldChange(diff.Fields, "name", existing.Name, updated.Name)
+	diff.Fields = appendFieldChange(diff.Fields, "height", existing.Height, updated.Height)
+	diff.Fields = appendFieldChange(diff.Fields, "weight", existing.Weight, updated.Weight)
+	diff.Fields = appendFieldChange(diff.Fields, "base_experience", existing.BaseExp, updated.BaseExp)
+	diff.Fields = appendFieldChange(diff.Fields, "order", existing.OrderNum, updated.OrderNum)
+	diff.Fields = appendFieldChange(diff.Fields, "species_id", optionalID(existing.SpeciesID), optionalID(updated.SpeciesID))
+	diff.Fields = appendFieldChange(diff.Fields, "is_default", existing.IsDefault, updated.IsDefault)
+	diff.Fields = appendFieldChange(diff.Fields, "form_name", existing.FormName, updated.FormName)
+	for _, variant := range SpriteVariants {
+		existingURL, _ := existing.Sprites.URL(variant)
+		updatedURL, _ := updated.Sprites.URL(variant)
+		diff.Fields = appendFieldChange(diff.Fields, "sprites."+variant, existingURL, updatedURL)
+	}
+
+	// Compare types
+	existingTypes := make([]string, len(existing.Types))
+	for i, t := range existing.Types {
+		existingTypes[i] = t.Type.Name
+	}
+	updatedTypes := make([]string, len(updated.Types))
+	for i, t := range updated.Types {
+		updatedTypes[i] = t.Type.Name
+	}
+	diff.AddedTypes, diff.RemovedTypes = diffNames(existingTypes, updatedTypes)
+	existingTypeSlots := make(map[string]int, len(existing.Types))
+	for _, t := range existing.Types {
+		existingTypeSlots[t.Type.Name] = t.Slot
+	}
+	updatedTypeSlots := make(map[string]int, len(updated.Types))
+	for _, t := range updated.Types {
+		updatedTypeSlots[t.Type.Name] = t.Slot
+	}
+	diff.Fields = append(diff.Fields, diffSlots("types", existingTypeSlots, updatedTypeSlots)...)
+
+	// Compare abilities
+	existingAbilities := make([]string, len(existing.Abilities))
+	for i, a := range existing.Abilities {
+		existingAbilities[i] = abilityKey(a)
+	}
+	updatedAbilities := make([]string, len(updated.Abilities))
+	for i, a := range updated.Abilities {
+		updatedAbilities[i] = abilityKey(a)
+	}
+	diff.AddedAbilities, diff.RemovedAbilities = diffNames(existingAbilities, updatedAbilities)
+	existingAbilitySlots := make(map[string]int, len(existing.Abilities))
+	for _, a := range existing.Abilities {
+		existingAbilitySlots[abilityKey(a)] = a.Slot
+	}
+	updatedAbilitySlots := make(map[string]int, len(updated.Abilities))
+	for _, a := range updated.Abilities {
+		updatedAbilitySlots[abilityKey(a)] = a.Slot
+	}
+	diff.Fields = append(diff.Fields, diffSlots("abilities", existingAbilitySlots, updatedAbilitySlots)...)
+
+	// Compare stats
+	diff.Stats = diffStats(existing.Stats, updated.Stats)
+
+	// Compare learnsets
+	existingMoves := make([]string, len(existing.Moves))
+	for i, m := range existing.Moves {
+		existingMoves[i] = moveKey(m)
+	}
+	updatedMoves := make([]string, len(updated.Moves))
+	for i, m := range updated.Moves {
+		updatedMoves[i] = moveKey(m)
+	}
+	diff.AddedMoves, diff.RemovedMoves = diffNames(existingMoves, updatedMoves)
+
+	return diff
+}
+
+func appendFieldChange(changes []FieldChange, field string, old, new interface{}) []FieldChange {
+	if old == new {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Old: old, New: new})
+}
+
+// optionalID unwraps a nullable ID so that it compares by value.
+func optionalID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// diffStats reports base stat and effort changes as "<stat>.base_stat" and
+// "<stat>.effort" fields. A stat missing on one side has a nil value there.
+func diffStats(existing, updated []PokemonStat) []FieldChange {
+	existingByName := make(map[string]PokemonStat, len(existing))
+	for _, stat := range existing {
+		existingByName[stat.StatName] = stat
+	}
+	updatedByName := make(map[string]PokemonStat, len(updated))
+	for _, stat := range updated {
+		updatedByName[stat.StatName] = stat
+	}
+
+	names := make([]string, 0, len(existingByName)+len(updatedByName))
+	for name := range existingByName {
+		names = append(names, name)
+	}
+	for name := range updatedByName {
+		if _, ok := existingByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []FieldChange
+	for _, name := range names {
+		old, hasOld := existingByName[name]
+		new, hasNew := updatedByName[name]
+
+		switch {
+		case !hasOld:
+			changes = append(changes,
+				FieldChange{Field: name + ".base_stat", New: new.BaseStat},
+				FieldChange{Field: name + ".effort", New: new.Effort})
+		case !hasNew:
+			changes = append(changes,
+				FieldChange{Field: name + ".base_stat", Old: old.BaseStat},
+				FieldChange{Field: name + ".effort", Old: old.Effort})
+		default:
+			changes = appendFieldChange(changes, name+".base_stat", old.BaseStat, new.BaseStat)
+			changes = appendFieldChange(changes, name+".effort", old.Effort, new.Effort)
+		}
+	}
+
+	return changes
+}
+
+// diffSlots reports the types or abilities kept on both sides whose slot
+// moved, as "<group>.<name>.slot" fields. Added and removed ones are
+// already listed by name.
+func diffSlots(group string, existing, updated map[string]int) []FieldChange {
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		if _, ok := updated[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []FieldChange
+	for _, name := range names {
+		changes = appendFieldChange(changes, group+"."+name+".slot", existing[name], updated[name])
+	}
+	return changes
+}
+
+// abilityKey tells a hidden ability apart from the same ability in a
+// regular slot.
+func abilityKey(a PokemonAbility) string {
+	if a.IsHidden {
+		return fmt.Sprintf("%s (hidden)", a.Ability.Name)
+	}
+	return a.Ability.Name
+}
+
+// moveKey describes one learnset entry, e.g. "thunderbolt (machine,
+// sword-shield)" or "thunder-shock (level-up 1, red-blue)".
+func moveKey(m PokemonMove) string {
+	method := m.LearnMethod
+	if m.Level > 0 {
+		method = fmt.Sprintf("%s %d", method, m.Level)
+	}
+	return fmt.Sprintf("%s (%s, %s)", m.MoveName, method, m.VersionGroup)
+}
+
+// diffNames compares two multisets of names and returns the ones only
+// present in updated and the ones only present in existing.
+func diffNames(existing, updated []string) (added, removed []string) {
+	counts := make(map[string]int)
+	for _, name := range existing {
+		counts[name]++
+	}
+	for _, name := range updated {
+		counts[name]--
+	}
+
+	for name, count := range counts {
+		for ; count < 0; count++ {
+			added = append(added, name)
+		}
+		for ; count > 0; count-- {
+			removed = append(removed, name)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
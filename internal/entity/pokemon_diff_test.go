@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// diffBase returns a stored pikachu to compare edited copies against.
+func diffBase() *Pokemon {
+	species := uint(25)
+	return &Pokemon{
+		ID:        25,
+		Name:      "pikachu",
+		Height:    4,
+		Weight:    60,
+		BaseExp:   112,
+		OrderNum:  35,
+		SpeciesID: &species,
+		IsDefault: true,
+		Sprites:   PokemonSprites{FrontDefault: "https://example.com/25.png"},
+		Types:     []PokemonType{{Slot: 1, Type: Type{Name: "electric"}}},
+		Abilities: []PokemonAbility{
+			{Slot: 1, Ability: Ability{Name: "static"}},
+			{Slot: 3, IsHidden: true, Ability: Ability{Name: "lightning-rod"}},
+		},
+		Stats: []PokemonStat{
+			{StatName: "hp", BaseStat: 35},
+			{StatName: "speed", BaseStat: 90, Effort: 2},
+		},
+		Moves: []PokemonMove{
+			{MoveName: "thunder-shock", LearnMethod: "level-up", Level: 1, VersionGroup: "red-blue"},
+			{MoveName: "thunderbolt", LearnMethod: "machine", VersionGroup: "red-blue"},
+		},
+	}
+}
+
+func TestDiffPokemon(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(p *Pokemon)
+		want PokemonDiff
+	}{
+		{
+			name: "unchanged",
+			edit: func(p *Pokemon) {},
+			want: PokemonDiff{},
+		},
+		{
+			name: "IDs and timestamps are ignored",
+			edit: func(p *Pokemon) {
+				p.ID = 10025
+				p.UpdatedAt = time.Now()
+				p.Types[0].ID = 7
+				p.Stats[0].PokemonID = 10025
+			},
+			want: PokemonDiff{},
+		},
+		{
+			name: "same species ID in another pointer",
+			edit: func(p *Pokemon) {
+				species := uint(25)
+				p.SpeciesID = &species
+			},
+			want: PokemonDiff{},
+		},
+		{
+			name: "renamed",
+			edit: func(p *Pokemon) { p.Name = "pikachu-renamed" },
+			want: PokemonDiff{Fields: []FieldChange{{Field: "name", Old: "pikachu", New: "pikachu-renamed"}}},
+		},
+		{
+			name: "scalar fields",
+			edit: func(p *Pokemon) {
+				p.Height = 5
+				p.BaseExp = 120
+				p.IsDefault = false
+				p.FormName = "gmax"
+			},
+			want: PokemonDiff{Fields: []FieldChange{
+				{Field: "height", Old: 4, New: 5},
+				{Field: "base_experience", Old: 112, New: 120},
+				{Field: "is_default", Old: true, New: false},
+				{Field: "form_name", Old: "", New: "gmax"},
+			}},
+		},
+		{
+			name: "species removed",
+			edit: func(p *Pokemon) { p.SpeciesID = nil },
+			want: PokemonDiff{Fields: []FieldChange{{Field: "species_id", Old: uint(25), New: nil}}},
+		},
+		{
+			name: "sprite added",
+			edit: func(p *Pokemon) { p.Sprites.FrontShiny = "https://example.com/shiny/25.png" },
+			want: PokemonDiff{Fields: []FieldChange{{Field: "sprites.front_shiny", Old: "", New: "https://example.com/shiny/25.png"}}},
+		},
+		{
+			name: "type added",
+			edit: func(p *Pokemon) {
+				p.Types = append(p.Types, PokemonType{Slot: 2, Type: Type{Name: "fairy"}})
+			},
+			want: PokemonDiff{AddedTypes: []string{"fairy"}},
+		},
+		{
+			name: "type replaced",
+			edit: func(p *Pokemon) { p.Types[0].Type.Name = "steel" },
+			want: PokemonDiff{AddedTypes: []string{"steel"}, RemovedTypes: []string{"electric"}},
+		},
+		{
+			name: "type slot moved",
+			edit: func(p *Pokemon) { p.Types[0].Slot = 2 },
+			want: PokemonDiff{Fields: []FieldChange{{Field: "types.electric.slot", Old: 1, New: 2}}},
+		},
+		{
+			name: "ability became hidden",
+			edit: func(p *Pokemon) {
+				p.Abilities[0].IsHidden = true
+			},
+			want: PokemonDiff{AddedAbilities: []string{"static (hidden)"}, RemovedAbilities: []string{"static"}},
+		},
+		{
+			name: "ability slot moved",
+			edit: func(p *Pokemon) { p.Abilities[0].Slot = 2 },
+			want: PokemonDiff{Fields: []FieldChange{{Field: "abilities.static.slot", Old: 1, New: 2}}},
+		},
+		{
+			name: "stats changed, added and removed",
+			edit: func(p *Pokemon) {
+				p.Stats = []PokemonStat{
+					{StatName: "attack", BaseStat: 55},
+					{StatName: "hp", BaseStat: 35, Effort: 1},
+				}
+			},
+			want: PokemonDiff{Stats: []FieldChange{
+				{Field: "attack.base_stat", New: 55},
+				{Field: "attack.effort", New: 0},
+				{Field: "hp.effort", Old: 0, New: 1},
+				{Field: "speed.base_stat", Old: 90},
+				{Field: "speed.effort", Old: 2},
+			}},
+		},
+		{
+			name: "move learned at another level",
+			edit: func(p *Pokemon) { p.Moves[0].Level = 5 },
+			want: PokemonDiff{
+				AddedMoves:   []string{"thunder-shock (level-up 5, red-blue)"},
+				RemovedMoves: []string{"thunder-shock (level-up 1, red-blue)"},
+			},
+		},
+		{
+			name: "repeated move counts once more",
+			edit: func(p *Pokemon) { p.Moves = append(p.Moves, p.Moves[1]) },
+			want: PokemonDiff{AddedMoves: []string{"thunderbolt (machine, red-blue)"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := diffBase()
+			tt.edit(updated)
+
+			diff := DiffPokemon(diffBase(), updated)
+			if !reflect.DeepEqual(diff, tt.want) {
+				t.Errorf("DiffPokemon = %+v, want %+v", diff, tt.want)
+			}
+			if diff.IsEmpty() != reflect.DeepEqual(tt.want, PokemonDiff{}) {
+				t.Errorf("IsEmpty = %v for %+v", diff.IsEmpty(), diff)
+			}
+		})
+	}
+}
@@ -4,16 +4,20 @@ import (
 	"time"
 )
 
+// PokemonAbility links a Pokemon to one of its abilities.
+// Slot orders the abilities of a Pokemon, the hidden one coming last.
 type PokemonAbility struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	PokemonID   uint      `json:"pokemon_id" gorm:"not null;index:idx_pokemon_ability_pokemon_id"`
-	AbilityName string    `json:"ability_name" gorm:"size:100;not null"`
-	IsHidden    bool      `json:"is_hidden" gorm:"default:false"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	PokemonID uint      `json:"pokemon_id" gorm:"not null;index:idx_pokemon_ability_pokemon_id;uniqueIndex:idx_pokemon_ability_pokemon_id_ability_id"`
+	AbilityID uint      `json:"ability_id" gorm:"not null;uniqueIndex:idx_pokemon_ability_pokemon_id_ability_id;index:idx_pokemon_ability_ability_id"`
+	IsHidden  bool      `json:"is_hidden" gorm:"default:false;uniqueIndex:idx_pokemon_ability_pokemon_id_ability_id"`
+	Slot      int       `json:"slot" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 
-	// Foreign key relationship
+	// Foreign key relationships
 	Pokemon Pokemon `json:"pokemon" gorm:"foreignKey:PokemonID;constraint:OnDelete:CASCADE"`
+	Ability Ability `json:"ability" gorm:"foreignKey:AbilityID"`
 }
 
 func (PokemonAbility) TableName() string {
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"time"
+)
+
+// PokemonHistory is one entry in the change log of a Pokemon, written each
+// time a sync updates it. Versions count up from 1 per Pokemon.
+type PokemonHistory struct {
+	ID        uint            `json:"id" gorm:"primaryKey"`
+	PokemonID uint            `json:"pokemon_id" gorm:"not null;uniqueIndex:idx_pokemon_history_pokemon_id_version"`
+	Version   int             `json:"version" gorm:"not null;uniqueIndex:idx_pokemon_history_pokemon_id_version"`
+	SyncRunID *uint           `json:"sync_run_id" gorm:"index:idx_pokemon_history_sync_run_id"`
+	Previous  PokemonSnapshot `json:"previous" gorm:"type:json;serializer:json;not null"`
+	Current   PokemonSnapshot `json:"current" gorm:"type:json;serializer:json;not null"`
+	Diff      PokemonDiff     `json:"diff" gorm:"type:json;serializer:json;not null"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (PokemonHistory) TableName() string {
+	return "pokemon_history"
+}
+
+// PokemonSnapshot is the upstream data of a Pokemon at one version. The
+// learnset is left out, as it can run to hundreds of entries; changes to it
+// are still in the diff.
+type PokemonSnapshot struct {
+	Name      string              `json:"name"`
+	Height    int                 `json:"height"`
+	Weight    int                 `json:"weight"`
+	BaseExp   int                 `json:"base_experience"`
+	Order     int                 `json:"order"`
+	SpeciesID *uint               `json:"species_id"`
+	IsDefault bool                `json:"is_default"`
+	FormName  string              `json:"form_name"`
+	Sprites   PokemonSprites      `json:"sprites"`
+	Types     []string            `json:"types"`
+	Abilities []string            `json:"abilities"`
+	Stats     []PokemonStatValues `json:"stats"`
+}
+
+type PokemonStatValues struct {
+	Name     string `json:"name"`
+	BaseStat int    `json:"base_stat"`
+	Effort   int    `json:"effort"`
+}
+
+// NewPokemonSnapshot captures the upstream data of a Pokemon. Abilities
+// are described as in PokemonDiff, e.g. "chlorophyll (hidden)".
+func NewPokemonSnapshot(pokemon *Pokemon) PokemonSnapshot {
+	snapshot := PokemonSnapshot{
+		Name:      pokemon.Name,
+		Height:    pokemon.Height,
+		Weight:    pokemon.Weight,
+		BaseExp:   pokemon.BaseExp,
+		Order:     pokemon.OrderNum,
+		SpeciesID: pokemon.SpeciesID,
+		IsDefault: pokemon.IsDefault,
+		FormName:  pokemon.FormName,
+		Sprites:   pokemon.Sprites,
+		Types:     make([]string, len(pokemon.Types)),
+		Abilities: make([]string, len(pokemon.Abilities)),
+		Stats:     make([]PokemonStatValues, len(pokemon.Stats)),
+	}
+	for i, t := range pokemon.Types {
+		snapshot.Types[i] = t.Type.Name
+	}
+	for i, a := range pokemon.Abilities {
+		snapshot.Abilities[i] = abilityKey(a)
+	}
+	for i, s := range pokemon.Stats {
+		snapshot.Stats[i] = PokemonStatValues{Name: s.StatName, BaseStat: s.BaseStat, Effort: s.Effort}
+	}
+	return snapshot
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+)
+
+// PokemonMove records one way a Pokemon learns a move in a version group.
+// Level is 0 for learn methods other than level-up.
+type PokemonMove struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	PokemonID    uint      `json:"pokemon_id" gorm:"not null;uniqueIndex:idx_pokemon_move_learn"`
+	MoveID       uint      `json:"move_id" gorm:"not null;uniqueIndex:idx_pokemon_move_learn;index:idx_pokemon_move_move_id"`
+	MoveName     string    `json:"move_name" gorm:"size:255;not null"`
+	LearnMethod  string    `json:"learn_method" gorm:"size:50;not null;uniqueIndex:idx_pokemon_move_learn"`
+	Level        int       `json:"level" gorm:"default:0;uniqueIndex:idx_pokemon_move_learn"`
+	VersionGroup string    `json:"version_group" gorm:"size:100;not null;uniqueIndex:idx_pokemon_move_learn;index:idx_pokemon_move_move_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Foreign key relationships
+	Pokemon Pokemon `json:"pokemon" gorm:"foreignKey:PokemonID;constraint:OnDelete:CASCADE"`
+	Move    *Move   `json:"move,omitempty" gorm:"foreignKey:MoveID"`
+}
+
+func (PokemonMove) TableName() string {
+	return "pokemon_move"
+}
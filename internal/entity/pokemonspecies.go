@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+// PokemonSpecies groups the Pokemon that are forms of the same species and
+// ties them to their evolution chain. IDs are the upstream species IDs.
+type PokemonSpecies struct {
+	ID                   uint      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	Name                 string    `json:"name" gorm:"uniqueIndex:idx_pokemon_species_name;size:255;not null"`
+	EvolutionChainID     *uint     `json:"evolution_chain_id"`
+	EvolvesFromSpeciesID *uint     `json:"evolves_from_species_id"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+
+	// Relationships
+	Translations []PokemonSpeciesTranslation `json:"translations,omitempty" gorm:"foreignKey:SpeciesID"`
+}
+
+// Translate returns the localized name and flavor text of the species in
+// language, falling back to DefaultLanguage.
+func (s *PokemonSpecies) Translate(language string) (Translation, bool) {
+	return translate(s.Translations, language)
+}
+
+func (PokemonSpecies) TableName() string {
+	return "pokemon_species"
+}
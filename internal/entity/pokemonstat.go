@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+type PokemonStat struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	PokemonID uint      `json:"pokemon_id" gorm:"not null;uniqueIndex:idx_pokemon_stat_pokemon_id_stat_name"`
+	StatName  string    `json:"stat_name" gorm:"size:50;not null;uniqueIndex:idx_pokemon_stat_pokemon_id_stat_name"`
+	BaseStat  int       `json:"base_stat" gorm:"default:0"`
+	Effort    int       `json:"effort" gorm:"default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Foreign key relationship
+	Pokemon Pokemon `json:"pokemon" gorm:"foreignKey:PokemonID;constraint:OnDelete:CASCADE"`
+}
+
+func (PokemonStat) TableName() string {
+	return "pokemon_stat"
+}
@@ -4,15 +4,19 @@ import (
 	"time"
 )
 
+// PokemonType links a Pokemon to one of its types.
+// Slot orders the types of a Pokemon, slot 1 being its primary type.
 type PokemonType struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
-	PokemonID uint      `json:"pokemon_id" gorm:"not null;index:idx_pokemon_type_pokemon_id"`
-	TypeName  string    `json:"type_name" gorm:"size:100;not null;index:idx_pokemon_type_type_name"`
+	PokemonID uint      `json:"pokemon_id" gorm:"not null;index:idx_pokemon_type_pokemon_id;uniqueIndex:idx_pokemon_type_pokemon_id_type_id"`
+	TypeID    uint      `json:"type_id" gorm:"not null;uniqueIndex:idx_pokemon_type_pokemon_id_type_id;index:idx_pokemon_type_type_id"`
+	Slot      int       `json:"slot" gorm:"not null;default:0"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
-	// Foreign key relationship
+	// Foreign key relationships
 	Pokemon Pokemon `json:"pokemon" gorm:"foreignKey:PokemonID;constraint:OnDelete:CASCADE"`
+	Type    Type    `json:"type" gorm:"foreignKey:TypeID"`
 }
 
 func (PokemonType) TableName() string {
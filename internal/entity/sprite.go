@@ -0,0 +1,9 @@
+package entity
+
+// Sprite is a sprite image ready to be served. Key identifies the upstream
+// image, so it changes whenever the image does.
+type Sprite struct {
+	Key         string
+	ContentType string
+	Data        []byte
+}
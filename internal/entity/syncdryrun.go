@@ -0,0 +1,21 @@
+package entity
+
+// PokemonChange is what a sync would do to a single Pokemon.
+type PokemonChange struct {
+	PokemonID int          `json:"pokemon_id"`
+	Name      string       `json:"name"`
+	Action    UpsertResult `json:"action"`
+	Diff      PokemonDiff  `json:"diff"`
+}
+
+// SyncDryRun previews a sync without writing anything. Changes only lists
+// the Pokemon that would be created or updated.
+type SyncDryRun struct {
+	Total          int             `json:"total"`
+	CreateCount    int             `json:"create_count"`
+	UpdateCount    int             `json:"update_count"`
+	UnchangedCount int             `json:"unchanged_count"`
+	ErrorCount     int             `json:"error_count"`
+	Changes        []PokemonChange `json:"changes"`
+	Failures       []SyncFailure   `json:"failures"`
+}
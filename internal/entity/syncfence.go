@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+)
+
+// SyncFence holds the highest fencing token seen for a named lock. Writes
+// made under an older token are rejected.
+type SyncFence struct {
+	Name      string    `json:"name" gorm:"primaryKey;size:100"`
+	Token     int64     `json:"token" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (SyncFence) TableName() string {
+	return "sync_fences"
+}
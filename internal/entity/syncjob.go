@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+)
+
+// SyncJob is the live view of a sync running in the background. It only
+// lives in memory; the durable record of the same sync is its SyncRun.
+type SyncJob struct {
+	ID           string        `json:"id"`
+	RunID        uint          `json:"run_id"`
+	TriggeredBy  SyncTrigger   `json:"triggered_by"`
+	State        SyncRunStatus `json:"state"`
+	Processed    int           `json:"processed"`
+	Total        int           `json:"total"`
+	SuccessCount int           `json:"success_count"`
+	SkipCount    int           `json:"skip_count"`
+	ErrorCount   int           `json:"error_count"`
+	Error        string        `json:"error"`
+	Failures     []SyncFailure `json:"failures"`
+	StartedAt    time.Time     `json:"started_at"`
+	FinishedAt   *time.Time    `json:"finished_at"`
+}
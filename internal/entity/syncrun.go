@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+type SyncTrigger string
+
+const (
+	SyncTriggerCron SyncTrigger = "cron"
+	SyncTriggerAPI  SyncTrigger = "api"
+	SyncTriggerCLI  SyncTrigger = "cli"
+)
+
+type SyncRunStatus string
+
+const (
+	SyncRunStatusRunning   SyncRunStatus = "running"
+	SyncRunStatusCompleted SyncRunStatus = "completed"
+	SyncRunStatusPartial   SyncRunStatus = "partial"
+	SyncRunStatusFailed    SyncRunStatus = "failed"
+)
+
+// SyncFailure records why a single Pokemon could not be synced.
+type SyncFailure struct {
+	PokemonID int    `json:"pokemon_id"`
+	Reason    string `json:"reason"`
+}
+
+type SyncRun struct {
+	ID           uint          `json:"id" gorm:"primaryKey"`
+	TriggeredBy  SyncTrigger   `json:"triggered_by" gorm:"column:triggered_by;size:20;not null"`
+	Status       SyncRunStatus `json:"status" gorm:"size:20;not null;index:idx_sync_runs_status"`
+	StartedAt    time.Time     `json:"started_at" gorm:"not null;index:idx_sync_runs_started_at"`
+	FinishedAt   *time.Time    `json:"finished_at"`
+	SuccessCount int           `json:"success_count" gorm:"default:0"`
+	SkipCount    int           `json:"skip_count" gorm:"default:0"`
+	ErrorCount   int           `json:"error_count" gorm:"default:0"`
+	Error        string        `json:"error" gorm:"type:text"`
+	Failures     []SyncFailure `json:"failures" gorm:"type:json;serializer:json"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+func (SyncRun) TableName() string {
+	return "sync_runs"
+}
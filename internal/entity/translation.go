@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"time"
+)
+
+// DefaultLanguage is the language served when a resource has no
+// translation in the requested one.
+const DefaultLanguage = "en"
+
+// Translation is the localized name and description of a resource in one
+// upstream language, e.g. "en" or "ja".
+type Translation struct {
+	Language    string `json:"language" gorm:"size:20;not null"`
+	Name        string `json:"name" gorm:"size:255;not null"`
+	Description string `json:"description" gorm:"type:text;not null"`
+}
+
+func (t Translation) translation() Translation {
+	return t
+}
+
+// translate returns the translation in language, falling back to
+// DefaultLanguage when there is none. The Language of the result is the
+// language actually served, so callers can tell a fallback apart.
+func translate[T interface{ translation() Translation }](rows []T, language string) (Translation, bool) {
+	var fallback *Translation
+	for _, row := range rows {
+		t := row.translation()
+		if t.Language == language {
+			return t, true
+		}
+		if t.Language == DefaultLanguage {
+			fallback = &t
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return Translation{}, false
+}
+
+// PokemonSpeciesTranslation is the localized name and flavor text of a
+// species.
+type PokemonSpeciesTranslation struct {
+	ID          uint `json:"id" gorm:"primaryKey"`
+	SpeciesID   uint `json:"species_id" gorm:"not null;uniqueIndex:idx_pokemon_species_translation_species_id_language"`
+	Translation `gorm:"embedded"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (PokemonSpeciesTranslation) TableName() string {
+	return "pokemon_species_translation"
+}
+
+// TypeTranslation is the localized name of a type. Types have no
+// description.
+type TypeTranslation struct {
+	ID          uint `json:"id" gorm:"primaryKey"`
+	TypeID      uint `json:"type_id" gorm:"not null;uniqueIndex:idx_type_translation_type_id_language"`
+	Translation `gorm:"embedded"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (TypeTranslation) TableName() string {
+	return "type_translation"
+}
+
+// AbilityTranslation is the localized name and flavor text of an ability.
+type AbilityTranslation struct {
+	ID          uint `json:"id" gorm:"primaryKey"`
+	AbilityID   uint `json:"ability_id" gorm:"not null;uniqueIndex:idx_ability_translation_ability_id_language"`
+	Translation `gorm:"embedded"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (AbilityTranslation) TableName() string {
+	return "ability_translation"
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+// Type is an entry in the types master table, shared by every Pokemon of
+// that type. DamageRelationsSyncedAt is nil until the damage relations of
+// the type have been synced; some types, such as unknown, have none.
+type Type struct {
+	ID                      uint       `json:"id" gorm:"primaryKey"`
+	Name                    string     `json:"name" gorm:"uniqueIndex:idx_type_name;size:100;not null"`
+	DamageRelationsSyncedAt *time.Time `json:"damage_relations_synced_at,omitempty"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+
+	// Relationships
+	Translations []TypeTranslation `json:"translations,omitempty" gorm:"foreignKey:TypeID"`
+}
+
+// Translate returns the localized name of the type in language, falling
+// back to DefaultLanguage.
+func (t *Type) Translate(language string) (Translation, bool) {
+	return translate(t.Translations, language)
+}
+
+func (Type) TableName() string {
+	return "type"
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+)
+
+// Damage multipliers of the upstream damage relations.
+const (
+	DamageMultiplierDouble float64 = 2
+	DamageMultiplierHalf   float64 = 0.5
+	DamageMultiplierNone   float64 = 0
+)
+
+// TypeDamageRelation is the multiplier applied to moves of the attacking
+// type against Pokemon of the defending type. Pairs without a relation deal
+// regular damage.
+type TypeDamageRelation struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	AttackingTypeID uint      `json:"attacking_type_id" gorm:"not null;uniqueIndex:idx_type_damage_relation_attacking_defending"`
+	DefendingTypeID uint      `json:"defending_type_id" gorm:"not null;uniqueIndex:idx_type_damage_relation_attacking_defending;index:idx_type_damage_relation_defending_type_id"`
+	Multiplier      float64   `json:"multiplier" gorm:"type:decimal(3,2);not null"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Foreign key relationships
+	AttackingType Type `json:"attacking_type" gorm:"foreignKey:AttackingTypeID;constraint:OnDelete:CASCADE"`
+	DefendingType Type `json:"defending_type" gorm:"foreignKey:DefendingTypeID;constraint:OnDelete:CASCADE"`
+}
+
+func (TypeDamageRelation) TableName() string {
+	return "type_damage_relation"
+}
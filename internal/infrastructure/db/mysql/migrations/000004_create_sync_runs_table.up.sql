@@ -0,0 +1,16 @@
+CREATE TABLE sync_runs (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  triggered_by VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  finished_at TIMESTAMP NULL DEFAULT NULL,
+  success_count INT DEFAULT 0,
+  skip_count INT DEFAULT 0,
+  error_count INT DEFAULT 0,
+  error TEXT,
+  failures JSON,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  INDEX idx_sync_runs_status (status),
+  INDEX idx_sync_runs_started_at (started_at)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE sync_fences;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE sync_fences (
+  name VARCHAR(100) NOT NULL PRIMARY KEY,
+  token BIGINT NOT NULL,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE pokemon_stat;
\ No newline at end of file
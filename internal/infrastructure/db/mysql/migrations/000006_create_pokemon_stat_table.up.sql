@@ -0,0 +1,12 @@
+CREATE TABLE pokemon_stat (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  pokemon_id INT NOT NULL,
+  stat_name VARCHAR(50) NOT NULL,
+  base_stat INT DEFAULT 0,
+  effort INT DEFAULT 0,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (pokemon_id) REFERENCES pokemon(id) ON DELETE CASCADE,
+  UNIQUE INDEX idx_pokemon_stat_pokemon_id_stat_name (pokemon_id, stat_name),
+  INDEX idx_pokemon_stat_stat_name_base_stat (stat_name, base_stat)
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE pokemon
+  DROP COLUMN sprite_front_default,
+  DROP COLUMN sprite_back_default,
+  DROP COLUMN sprite_front_shiny,
+  DROP COLUMN sprite_back_shiny;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE pokemon
+  ADD COLUMN sprite_front_default VARCHAR(512) NOT NULL DEFAULT '',
+  ADD COLUMN sprite_back_default VARCHAR(512) NOT NULL DEFAULT '',
+  ADD COLUMN sprite_front_shiny VARCHAR(512) NOT NULL DEFAULT '',
+  ADD COLUMN sprite_back_shiny VARCHAR(512) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE evolution_chain;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE evolution_chain_link;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE evolution_chain_link (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  chain_id INT NOT NULL,
+  species_id INT NOT NULL,
+  species_name VARCHAR(255) NOT NULL,
+  evolves_from_species_id INT NULL DEFAULT NULL,
+  is_baby BOOLEAN DEFAULT FALSE,
+  details JSON,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (chain_id) REFERENCES evolution_chain(id) ON DELETE CASCADE,
+  UNIQUE INDEX idx_evolution_chain_link_chain_id_species_id (chain_id, species_id)
+);
\ No newline at end of file
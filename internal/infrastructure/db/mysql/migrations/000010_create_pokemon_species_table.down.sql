@@ -0,0 +1 @@
+DROP TABLE pokemon_species;
\ No newline at end of file
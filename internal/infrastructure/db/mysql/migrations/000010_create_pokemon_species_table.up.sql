@@ -0,0 +1,10 @@
+CREATE TABLE pokemon_species (
+  id INT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  evolution_chain_id INT NULL DEFAULT NULL,
+  evolves_from_species_id INT NULL DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (evolution_chain_id) REFERENCES evolution_chain(id) ON DELETE SET NULL,
+  UNIQUE INDEX idx_pokemon_species_name (name)
+);
\ No newline at end of file
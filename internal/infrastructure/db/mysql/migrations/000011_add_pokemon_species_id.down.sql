@@ -0,0 +1,3 @@
+ALTER TABLE pokemon
+  DROP FOREIGN KEY fk_pokemon_species_id,
+  DROP COLUMN species_id;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE pokemon
+  ADD COLUMN species_id INT NULL DEFAULT NULL,
+  ADD CONSTRAINT fk_pokemon_species_id FOREIGN KEY (species_id) REFERENCES pokemon_species(id) ON DELETE SET NULL;
\ No newline at end of file
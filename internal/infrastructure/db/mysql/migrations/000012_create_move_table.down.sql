@@ -0,0 +1 @@
+DROP TABLE move;
\ No newline at end of file
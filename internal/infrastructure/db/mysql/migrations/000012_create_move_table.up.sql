@@ -0,0 +1,13 @@
+CREATE TABLE move (
+  id INT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  power INT NULL DEFAULT NULL,
+  accuracy INT NULL DEFAULT NULL,
+  pp INT NULL DEFAULT NULL,
+  damage_class VARCHAR(20),
+  type_name VARCHAR(100),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_move_name (name),
+  INDEX idx_move_type_name (type_name)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE pokemon_move;
\ No newline at end of file
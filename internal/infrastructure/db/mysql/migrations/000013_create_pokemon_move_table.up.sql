@@ -0,0 +1,15 @@
+CREATE TABLE pokemon_move (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  pokemon_id INT NOT NULL,
+  move_id INT NOT NULL,
+  move_name VARCHAR(255) NOT NULL,
+  learn_method VARCHAR(50) NOT NULL,
+  level INT DEFAULT 0,
+  version_group VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (pokemon_id) REFERENCES pokemon(id) ON DELETE CASCADE,
+  FOREIGN KEY (move_id) REFERENCES move(id),
+  UNIQUE INDEX idx_pokemon_move_learn (pokemon_id, move_id, version_group, learn_method, level),
+  INDEX idx_pokemon_move_move_id (move_id, version_group)
+);
\ No newline at end of file
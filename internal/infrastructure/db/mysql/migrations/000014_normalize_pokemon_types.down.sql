@@ -0,0 +1,16 @@
+ALTER TABLE pokemon_type ADD COLUMN type_name VARCHAR(100) NULL AFTER pokemon_id;
+
+UPDATE pokemon_type
+JOIN `type` ON `type`.id = pokemon_type.type_id
+SET pokemon_type.type_name = `type`.name;
+
+ALTER TABLE pokemon_type DROP FOREIGN KEY fk_pokemon_type_type_id;
+
+ALTER TABLE pokemon_type
+  MODIFY COLUMN type_name VARCHAR(100) NOT NULL,
+  DROP INDEX idx_pokemon_type_pokemon_id_type_id,
+  DROP INDEX idx_pokemon_type_type_id,
+  DROP COLUMN type_id,
+  ADD INDEX idx_pokemon_type_type_name (type_name);
+
+DROP TABLE `type`;
\ No newline at end of file
@@ -0,0 +1,31 @@
+CREATE TABLE `type` (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_type_name (name)
+);
+
+INSERT INTO `type` (name)
+SELECT DISTINCT type_name FROM pokemon_type ORDER BY type_name;
+
+-- Duplicate links carry no information and would break the unique index.
+DELETE duplicate FROM pokemon_type duplicate
+JOIN pokemon_type kept
+  ON kept.pokemon_id = duplicate.pokemon_id
+  AND kept.type_name = duplicate.type_name
+  AND kept.id < duplicate.id;
+
+ALTER TABLE pokemon_type ADD COLUMN type_id INT NULL AFTER pokemon_id;
+
+UPDATE pokemon_type
+JOIN `type` ON `type`.name = pokemon_type.type_name
+SET pokemon_type.type_id = `type`.id;
+
+ALTER TABLE pokemon_type
+  MODIFY COLUMN type_id INT NOT NULL,
+  DROP INDEX idx_pokemon_type_type_name,
+  DROP COLUMN type_name,
+  ADD CONSTRAINT fk_pokemon_type_type_id FOREIGN KEY (type_id) REFERENCES `type`(id),
+  ADD UNIQUE INDEX idx_pokemon_type_pokemon_id_type_id (pokemon_id, type_id),
+  ADD INDEX idx_pokemon_type_type_id (type_id);
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE pokemon_ability ADD COLUMN ability_name VARCHAR(100) NULL AFTER pokemon_id;
+
+UPDATE pokemon_ability
+JOIN ability ON ability.id = pokemon_ability.ability_id
+SET pokemon_ability.ability_name = ability.name;
+
+ALTER TABLE pokemon_ability DROP FOREIGN KEY fk_pokemon_ability_ability_id;
+
+ALTER TABLE pokemon_ability
+  MODIFY COLUMN ability_name VARCHAR(100) NOT NULL,
+  DROP INDEX idx_pokemon_ability_pokemon_id_ability_id,
+  DROP INDEX idx_pokemon_ability_ability_id,
+  DROP COLUMN ability_id;
+
+DROP TABLE ability;
\ No newline at end of file
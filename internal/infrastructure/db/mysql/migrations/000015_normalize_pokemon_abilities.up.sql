@@ -0,0 +1,31 @@
+CREATE TABLE ability (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_ability_name (name)
+);
+
+INSERT INTO ability (name)
+SELECT DISTINCT ability_name FROM pokemon_ability ORDER BY ability_name;
+
+-- Duplicate links carry no information and would break the unique index.
+DELETE duplicate FROM pokemon_ability duplicate
+JOIN pokemon_ability kept
+  ON kept.pokemon_id = duplicate.pokemon_id
+  AND kept.ability_name = duplicate.ability_name
+  AND kept.is_hidden = duplicate.is_hidden
+  AND kept.id < duplicate.id;
+
+ALTER TABLE pokemon_ability ADD COLUMN ability_id INT NULL AFTER pokemon_id;
+
+UPDATE pokemon_ability
+JOIN ability ON ability.name = pokemon_ability.ability_name
+SET pokemon_ability.ability_id = ability.id;
+
+ALTER TABLE pokemon_ability
+  MODIFY COLUMN ability_id INT NOT NULL,
+  DROP COLUMN ability_name,
+  ADD CONSTRAINT fk_pokemon_ability_ability_id FOREIGN KEY (ability_id) REFERENCES ability(id),
+  ADD UNIQUE INDEX idx_pokemon_ability_pokemon_id_ability_id (pokemon_id, ability_id, is_hidden),
+  ADD INDEX idx_pokemon_ability_ability_id (ability_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE type_damage_relation;
\ No newline at end of file
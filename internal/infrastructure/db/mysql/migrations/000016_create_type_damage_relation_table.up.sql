@@ -0,0 +1,12 @@
+CREATE TABLE type_damage_relation (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  attacking_type_id INT NOT NULL,
+  defending_type_id INT NOT NULL,
+  multiplier DECIMAL(3,2) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (attacking_type_id) REFERENCES `type`(id) ON DELETE CASCADE,
+  FOREIGN KEY (defending_type_id) REFERENCES `type`(id) ON DELETE CASCADE,
+  UNIQUE INDEX idx_type_damage_relation_attacking_defending (attacking_type_id, defending_type_id),
+  INDEX idx_type_damage_relation_defending_type_id (defending_type_id)
+);
\ No newline at end of file
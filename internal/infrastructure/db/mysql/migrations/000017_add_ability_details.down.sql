@@ -0,0 +1,4 @@
+ALTER TABLE ability
+  DROP COLUMN effect,
+  DROP COLUMN short_effect,
+  DROP COLUMN generation;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE ability
+  ADD COLUMN effect TEXT NOT NULL AFTER name,
+  ADD COLUMN short_effect TEXT NOT NULL AFTER effect,
+  ADD COLUMN generation VARCHAR(50) NOT NULL DEFAULT '' AFTER short_effect;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE pokemon_species_translation;
\ No newline at end of file
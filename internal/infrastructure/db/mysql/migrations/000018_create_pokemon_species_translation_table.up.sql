@@ -0,0 +1,11 @@
+CREATE TABLE pokemon_species_translation (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  species_id INT NOT NULL,
+  language VARCHAR(20) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  description TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (species_id) REFERENCES pokemon_species(id) ON DELETE CASCADE,
+  UNIQUE INDEX idx_pokemon_species_translation_species_id_language (species_id, language)
+);
\ No newline at end of file
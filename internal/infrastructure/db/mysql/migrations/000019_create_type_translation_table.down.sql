@@ -0,0 +1 @@
+DROP TABLE type_translation;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE type_translation (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  type_id INT NOT NULL,
+  language VARCHAR(20) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  description TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (type_id) REFERENCES `type`(id) ON DELETE CASCADE,
+  UNIQUE INDEX idx_type_translation_type_id_language (type_id, language)
+);
\ No newline at end of file
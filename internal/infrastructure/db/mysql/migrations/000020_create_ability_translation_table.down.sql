@@ -0,0 +1 @@
+DROP TABLE ability_translation;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE ability_translation (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  ability_id INT NOT NULL,
+  language VARCHAR(20) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  description TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (ability_id) REFERENCES ability(id) ON DELETE CASCADE,
+  UNIQUE INDEX idx_ability_translation_ability_id_language (ability_id, language)
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE pokemon
+  DROP INDEX idx_pokemon_deleted_at,
+  DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE pokemon
+  ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER updated_at,
+  ADD INDEX idx_pokemon_deleted_at (deleted_at);
\ No newline at end of file
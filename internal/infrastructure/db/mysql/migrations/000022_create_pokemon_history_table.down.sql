@@ -0,0 +1 @@
+DROP TABLE pokemon_history;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE pokemon_history (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  pokemon_id INT NOT NULL,
+  version INT NOT NULL,
+  sync_run_id INT NULL,
+  previous JSON NOT NULL,
+  current JSON NOT NULL,
+  diff JSON NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (pokemon_id) REFERENCES pokemon(id) ON DELETE CASCADE,
+  FOREIGN KEY (sync_run_id) REFERENCES sync_runs(id) ON DELETE SET NULL,
+  UNIQUE INDEX idx_pokemon_history_pokemon_id_version (pokemon_id, version),
+  INDEX idx_pokemon_history_sync_run_id (sync_run_id)
+);
\ No newline at end of file
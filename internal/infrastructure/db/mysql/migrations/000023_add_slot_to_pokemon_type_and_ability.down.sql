@@ -0,0 +1,2 @@
+ALTER TABLE pokemon_ability DROP COLUMN slot;
+ALTER TABLE pokemon_type DROP COLUMN slot;
\ No newline at end of file
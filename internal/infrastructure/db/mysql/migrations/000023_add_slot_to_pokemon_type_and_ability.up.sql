@@ -0,0 +1,22 @@
+ALTER TABLE pokemon_type ADD COLUMN slot INT NOT NULL DEFAULT 0 AFTER type_id;
+ALTER TABLE pokemon_ability ADD COLUMN slot INT NOT NULL DEFAULT 0 AFTER is_hidden;
+
+-- Links were inserted in upstream slot order. Filling the slots in here
+-- keeps the first sync after the migration from recording a slot change
+-- for every Pokemon. Upstream puts the hidden ability in slot 3.
+UPDATE pokemon_type
+JOIN (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY pokemon_id ORDER BY id) AS slot
+  FROM pokemon_type
+) numbered ON numbered.id = pokemon_type.id
+SET pokemon_type.slot = numbered.slot;
+
+UPDATE pokemon_ability
+JOIN (
+  SELECT id, CASE
+    WHEN is_hidden THEN 3
+    ELSE ROW_NUMBER() OVER (PARTITION BY pokemon_id, is_hidden ORDER BY id)
+  END AS slot
+  FROM pokemon_ability
+) numbered ON numbered.id = pokemon_ability.id
+SET pokemon_ability.slot = numbered.slot;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE pokemon
+  DROP INDEX idx_pokemon_form_name,
+  DROP INDEX idx_pokemon_is_default,
+  DROP COLUMN form_name,
+  DROP COLUMN is_default;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE pokemon
+  ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT TRUE AFTER species_id,
+  ADD COLUMN form_name VARCHAR(100) NOT NULL DEFAULT '' AFTER is_default,
+  ADD INDEX idx_pokemon_is_default (is_default),
+  ADD INDEX idx_pokemon_form_name (form_name);
\ No newline at end of file
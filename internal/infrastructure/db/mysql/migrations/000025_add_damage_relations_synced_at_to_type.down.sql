@@ -0,0 +1 @@
+ALTER TABLE `type` DROP COLUMN damage_relations_synced_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE `type` ADD COLUMN damage_relations_synced_at TIMESTAMP NULL AFTER name;
+
+-- Types with stored relations were synced before the column existed.
+UPDATE `type`
+SET damage_relations_synced_at = CURRENT_TIMESTAMP
+WHERE id IN (SELECT attacking_type_id FROM type_damage_relation);
\ No newline at end of file
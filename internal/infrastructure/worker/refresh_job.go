@@ -1,9 +1,11 @@
 package worker
 
 import (
+	"errors"
 	"log"
 	"time"
 
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
 	"github.com/AhmadNizar/cata-dtc/internal/usecase/pokemon"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/sony/gobreaker"
@@ -44,12 +46,21 @@ func (j *RefreshJob) Execute() {
 	j.logger.Printf("🛡️  Circuit breaker state: %v (failures: %d)", j.circuitBreaker.State(), j.circuitBreaker.Counts().ConsecutiveFailures)
 
 	// Execute with circuit breaker protection
+	skipped := false
 	_, err := j.circuitBreaker.Execute(func() (interface{}, error) {
-		return nil, j.executeWithRetry()
+		err := j.executeWithRetry()
+		if errors.Is(err, pokemon.ErrSyncInProgress) {
+			// Another sync is already doing the work; not a failure.
+			skipped = true
+			return nil, nil
+		}
+		return nil, err
 	})
 
 	duration := time.Since(startTime)
-	if err != nil {
+	if skipped {
+		j.logger.Printf("⏭️ [CRON] Scheduled Pokemon refresh SKIPPED: %v", pokemon.ErrSyncInProgress)
+	} else if err != nil {
 		j.logger.Printf("❌ [CRON] Scheduled Pokemon refresh FAILED after %v: %v", duration, err)
 		j.logger.Printf("🛡️  Circuit breaker state after failure: %v", j.circuitBreaker.State())
 	} else {
@@ -60,7 +71,11 @@ func (j *RefreshJob) Execute() {
 
 func (j *RefreshJob) executeWithRetry() error {
 	operation := func() error {
-		return j.pokemonService.SyncPokemonData()
+		err := j.pokemonService.SyncPokemonData(entity.SyncTriggerCron)
+		if errors.Is(err, pokemon.ErrSyncInProgress) {
+			return backoff.Permanent(err)
+		}
+		return err
 	}
 
 	// Exponential backoff configuration
@@ -0,0 +1,35 @@
+package presenter
+
+type Ability struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	FlavorText  string `json:"flavor_text"`
+	Effect      string `json:"effect"`
+	ShortEffect string `json:"short_effect"`
+	Generation  string `json:"generation"`
+}
+
+type AbilityList struct {
+	Items      []Ability `json:"items"`
+	Total      int64     `json:"total"`
+	Page       int       `json:"page,omitempty"`
+	Limit      int       `json:"limit"`
+	NextCursor *string   `json:"next_cursor"`
+}
+
+type AbilityPokemon struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	IsHidden    bool   `json:"is_hidden"`
+	Slot        int    `json:"slot"`
+}
+
+type AbilityDetail struct {
+	Ability Ability          `json:"ability"`
+	Items   []AbilityPokemon `json:"items"`
+	Total   int64            `json:"total"`
+	Page    int              `json:"page"`
+	Limit   int              `json:"limit"`
+}
@@ -0,0 +1,38 @@
+package presenter
+
+type EvolutionDetail struct {
+	Trigger               string `json:"trigger"`
+	MinLevel              *int   `json:"min_level,omitempty"`
+	Item                  string `json:"item,omitempty"`
+	HeldItem              string `json:"held_item,omitempty"`
+	MinHappiness          *int   `json:"min_happiness,omitempty"`
+	MinAffection          *int   `json:"min_affection,omitempty"`
+	MinBeauty             *int   `json:"min_beauty,omitempty"`
+	TimeOfDay             string `json:"time_of_day,omitempty"`
+	Gender                *int   `json:"gender,omitempty"`
+	KnownMove             string `json:"known_move,omitempty"`
+	KnownMoveType         string `json:"known_move_type,omitempty"`
+	Location              string `json:"location,omitempty"`
+	PartySpecies          string `json:"party_species,omitempty"`
+	PartyType             string `json:"party_type,omitempty"`
+	TradeSpecies          string `json:"trade_species,omitempty"`
+	RelativePhysicalStats *int   `json:"relative_physical_stats,omitempty"`
+	NeedsOverworldRain    bool   `json:"needs_overworld_rain,omitempty"`
+	TurnUpsideDown        bool   `json:"turn_upside_down,omitempty"`
+}
+
+// EvolutionNode is a species in an evolution tree. EvolutionDetails holds
+// the ways of evolving into it from its parent, and is empty at the root.
+type EvolutionNode struct {
+	SpeciesID        uint              `json:"species_id"`
+	Name             string            `json:"name"`
+	DisplayName      string            `json:"display_name"`
+	IsBaby           bool              `json:"is_baby"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []EvolutionNode   `json:"evolves_to"`
+}
+
+type EvolutionChain struct {
+	ID    uint          `json:"id"`
+	Chain EvolutionNode `json:"chain"`
+}
@@ -0,0 +1,35 @@
+package presenter
+
+// PokemonSnapshot is the upstream data of a Pokemon at one version. Sprites
+// are the upstream URLs of the time, keyed by variant.
+type PokemonSnapshot struct {
+	Name      string            `json:"name"`
+	Height    int               `json:"height"`
+	Weight    int               `json:"weight"`
+	BaseExp   int               `json:"base_experience"`
+	Order     int               `json:"order"`
+	SpeciesID *uint             `json:"species_id"`
+	IsDefault bool              `json:"is_default"`
+	FormName  string            `json:"form_name"`
+	Sprites   map[string]string `json:"sprites"`
+	Types     []string          `json:"types"`
+	Abilities []string          `json:"abilities"`
+	Stats     []PokemonStat     `json:"stats"`
+}
+
+type PokemonHistoryEntry struct {
+	Version   int             `json:"version"`
+	SyncRunID *uint           `json:"sync_run_id"`
+	Previous  PokemonSnapshot `json:"previous"`
+	Current   PokemonSnapshot `json:"current"`
+	Diff      PokemonDiff     `json:"diff"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type PokemonHistory struct {
+	PokemonID uint                  `json:"pokemon_id"`
+	Items     []PokemonHistoryEntry `json:"items"`
+	Total     int64                 `json:"total"`
+	Page      int                   `json:"page"`
+	Limit     int                   `json:"limit"`
+}
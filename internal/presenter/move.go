@@ -0,0 +1,37 @@
+package presenter
+
+type Move struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Power       *int   `json:"power"`
+	Accuracy    *int   `json:"accuracy"`
+	PP          *int   `json:"pp"`
+	DamageClass string `json:"damage_class"`
+	Type        string `json:"type"`
+}
+
+type PokemonMove struct {
+	Move         Move   `json:"move"`
+	LearnMethod  string `json:"learn_method"`
+	Level        int    `json:"level"`
+	VersionGroup string `json:"version_group"`
+}
+
+type PokemonLearnset struct {
+	PokemonID uint          `json:"pokemon_id"`
+	Moves     []PokemonMove `json:"moves"`
+}
+
+type PokemonSummary struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type MoveLearners struct {
+	Move       Move             `json:"move"`
+	Items      []PokemonSummary `json:"items"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page,omitempty"`
+	Limit      int              `json:"limit"`
+	NextCursor *string          `json:"next_cursor"`
+}
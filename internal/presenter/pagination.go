@@ -0,0 +1,9 @@
+package presenter
+
+// PageLinks holds the URLs of a page and its neighbours. Next and Prev are
+// null on the last and first page.
+type PageLinks struct {
+	Self string  `json:"self"`
+	Next *string `json:"next"`
+	Prev *string `json:"prev"`
+}
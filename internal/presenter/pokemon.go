@@ -1,30 +1,70 @@
 package presenter
 
 type PokemonType struct {
-	Name string `json:"name"`
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Slot        int    `json:"slot"`
 }
 
 type PokemonAbility struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	IsHidden    bool   `json:"is_hidden"`
+	Slot        int    `json:"slot"`
+}
+
+type PokemonStat struct {
 	Name     string `json:"name"`
-	IsHidden bool   `json:"is_hidden"`
+	BaseStat int    `json:"base_stat"`
+	Effort   int    `json:"effort"`
+}
+
+// PokemonSprites holds the URLs of this API's sprite endpoint, one per
+// variant, or null when there is no image for a variant.
+type PokemonSprites struct {
+	FrontDefault *string `json:"front_default"`
+	BackDefault  *string `json:"back_default"`
+	FrontShiny   *string `json:"front_shiny"`
+	BackShiny    *string `json:"back_shiny"`
 }
 
+// Pokemon carries both the upstream slug, Name, and the localized
+// DisplayName and FlavorText of its species. Forms of a species have
+// IsDefault unset and a FormName such as "alola".
 type Pokemon struct {
-	ID        uint             `json:"id"`
-	Name      string           `json:"name"`
-	Height    int              `json:"height"`
-	Weight    int              `json:"weight"`
-	BaseExp   int              `json:"base_experience"`
-	Order     int              `json:"order"`
-	Types     []PokemonType    `json:"types"`
-	Abilities []PokemonAbility `json:"abilities"`
-	CreatedAt string           `json:"created_at"`
-	UpdatedAt string           `json:"updated_at"`
+	ID          uint             `json:"id"`
+	Name        string           `json:"name"`
+	DisplayName string           `json:"display_name"`
+	FlavorText  string           `json:"flavor_text"`
+	Height      int              `json:"height"`
+	Weight      int              `json:"weight"`
+	BaseExp     int              `json:"base_experience"`
+	Order       int              `json:"order"`
+	IsDefault   bool             `json:"is_default"`
+	FormName    string           `json:"form_name"`
+	Types       []PokemonType    `json:"types"`
+	Abilities   []PokemonAbility `json:"abilities"`
+	Stats       []PokemonStat    `json:"stats"`
+	Sprites     PokemonSprites   `json:"sprites"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
 }
 
+// PokemonList is a page of Pokemon. Page is left out for pages reached by
+// cursor, and NextCursor is null on the last page.
 type PokemonList struct {
-	Items []Pokemon `json:"items"`
-	Total int64     `json:"total"`
-	Page  int       `json:"page"`
-	Limit int       `json:"limit"`
-}
\ No newline at end of file
+	Items      []Pokemon `json:"items"`
+	Total      int64     `json:"total"`
+	Page       int       `json:"page,omitempty"`
+	Limit      int       `json:"limit"`
+	TotalPages int       `json:"total_pages"`
+	NextCursor *string   `json:"next_cursor"`
+	Links      PageLinks `json:"links"`
+}
+
+type PokemonSyncResult struct {
+	Result  string  `json:"result"`
+	Pokemon Pokemon `json:"pokemon"`
+}
@@ -0,0 +1,76 @@
+package presenter
+
+type SyncFailure struct {
+	PokemonID int    `json:"pokemon_id"`
+	Reason    string `json:"reason"`
+}
+
+type SyncRun struct {
+	ID           uint          `json:"id"`
+	TriggeredBy  string        `json:"triggered_by"`
+	Status       string        `json:"status"`
+	StartedAt    string        `json:"started_at"`
+	FinishedAt   *string       `json:"finished_at"`
+	SuccessCount int           `json:"success_count"`
+	SkipCount    int           `json:"skip_count"`
+	ErrorCount   int           `json:"error_count"`
+	Error        string        `json:"error,omitempty"`
+	Failures     []SyncFailure `json:"failures"`
+}
+
+type SyncRunList struct {
+	Items []SyncRun `json:"items"`
+	Total int64     `json:"total"`
+	Page  int       `json:"page"`
+	Limit int       `json:"limit"`
+}
+
+type SyncJob struct {
+	ID           string        `json:"id"`
+	RunID        uint          `json:"run_id,omitempty"`
+	TriggeredBy  string        `json:"triggered_by"`
+	State        string        `json:"state"`
+	Processed    int           `json:"processed"`
+	Total        int           `json:"total"`
+	SuccessCount int           `json:"success_count"`
+	SkipCount    int           `json:"skip_count"`
+	ErrorCount   int           `json:"error_count"`
+	Error        string        `json:"error,omitempty"`
+	Failures     []SyncFailure `json:"failures"`
+	StartedAt    string        `json:"started_at"`
+	FinishedAt   *string       `json:"finished_at"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type PokemonDiff struct {
+	Fields           []FieldChange `json:"fields"`
+	AddedTypes       []string      `json:"added_types"`
+	RemovedTypes     []string      `json:"removed_types"`
+	AddedAbilities   []string      `json:"added_abilities"`
+	RemovedAbilities []string      `json:"removed_abilities"`
+	Stats            []FieldChange `json:"stats"`
+	AddedMoves       []string      `json:"added_moves"`
+	RemovedMoves     []string      `json:"removed_moves"`
+}
+
+type PokemonChange struct {
+	PokemonID int         `json:"pokemon_id"`
+	Name      string      `json:"name"`
+	Action    string      `json:"action"`
+	Diff      PokemonDiff `json:"diff"`
+}
+
+type SyncDryRun struct {
+	Total          int             `json:"total"`
+	CreateCount    int             `json:"create_count"`
+	UpdateCount    int             `json:"update_count"`
+	UnchangedCount int             `json:"unchanged_count"`
+	ErrorCount     int             `json:"error_count"`
+	Changes        []PokemonChange `json:"changes"`
+	Failures       []SyncFailure   `json:"failures"`
+}
@@ -0,0 +1,14 @@
+package presenter
+
+type TombstonedPokemon struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+type TombstonedPokemonList struct {
+	Items []TombstonedPokemon `json:"items"`
+	Total int64               `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
@@ -0,0 +1,33 @@
+package presenter
+
+type Type struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// DamageRelations groups types by the damage multiplier between them and
+// the matched type.
+type DamageRelations struct {
+	DoubleDamage []Type `json:"double_damage"`
+	HalfDamage   []Type `json:"half_damage"`
+	NoDamage     []Type `json:"no_damage"`
+}
+
+type TypeMatchups struct {
+	Type       Type            `json:"type"`
+	DamageTo   DamageRelations `json:"damage_to"`
+	DamageFrom DamageRelations `json:"damage_from"`
+}
+
+type TypeMultiplier struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+type Matchup struct {
+	AttackingType    string           `json:"attacking_type"`
+	DefendingPokemon *PokemonSummary  `json:"defending_pokemon,omitempty"`
+	DefendingTypes   []TypeMultiplier `json:"defending_types"`
+	Multiplier       float64          `json:"multiplier"`
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBlobNotFound is returned when nothing is stored under a key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobRepository stores opaque binary objects, such as downloaded sprite
+// images, under flat keys.
+type BlobRepository interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+}
@@ -0,0 +1,79 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+type blobRepository struct {
+	dir string
+}
+
+// NewBlobRepository stores blobs as files in dir, which is created on the
+// first write.
+func NewBlobRepository(dir string) repository.BlobRepository {
+	return &blobRepository{dir: dir}
+}
+
+func (r *blobRepository) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := r.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, repository.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+
+	return data, nil
+}
+
+// Put writes to a temporary file and renames it into place, so concurrent
+// readers never see a partially written blob.
+func (r *blobRepository) Put(ctx context.Context, key string, data []byte) error {
+	path, err := r.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return fmt.Errorf("creating blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(r.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("moving blob into place: %w", err)
+	}
+
+	return nil
+}
+
+// path maps a key to a file directly inside the blob directory, rejecting
+// keys that would escape it.
+func (r *blobRepository) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key[0] == '.' {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(r.dir, key), nil
+}
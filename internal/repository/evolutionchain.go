@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+type EvolutionChainRepository interface {
+	GetByIDWithLinks(ctx context.Context, id uint) (*entity.EvolutionChain, error)
+	// CreateOrUpdate saves a chain and replaces all of its links.
+	CreateOrUpdate(ctx context.Context, chain *entity.EvolutionChain) error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStaleFencingToken is returned when a write carries a fencing token
+// older than one that has already been seen.
+var ErrStaleFencingToken = errors.New("fencing token is stale")
+
+type FenceRepository interface {
+	// Advance raises the named fence by one and returns the new token.
+	// Tokens are issued by the same store that checks them, so they keep
+	// increasing even when the lock store loses its state.
+	Advance(ctx context.Context, name string) (int64, error)
+}
+
+type fenceContextKey struct{}
+
+type fence struct {
+	name  string
+	token int64
+}
+
+// WithFencingToken marks writes made with the returned context as made
+// under the given fence. Repositories that honour fencing reject them once
+// the fence has moved past token.
+func WithFencingToken(ctx context.Context, name string, token int64) context.Context {
+	return context.WithValue(ctx, fenceContextKey{}, fence{name: name, token: token})
+}
+
+// FencingTokenFromContext returns the fence attached by WithFencingToken.
+func FencingTokenFromContext(ctx context.Context) (string, int64, bool) {
+	f, ok := ctx.Value(fenceContextKey{}).(fence)
+	return f.name, f.token, ok
+}
@@ -3,8 +3,11 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
@@ -15,11 +18,19 @@ type pokemonAPIRepository struct {
 	baseURL    string
 	httpClient *http.Client
 	maxRetries int
+	limiter    *rateLimiter
+	validators *validatorStore
 }
 
+// maxSpriteBytes bounds a sprite download; upstream sprites are a few KB.
+const maxSpriteBytes = 5 << 20
+
 type Config struct {
 	BaseURL    string
 	MaxRetries int
+	// RequestsPerSecond caps outgoing requests across all callers of the
+	// repository. Zero disables the limit.
+	RequestsPerSecond int
 }
 
 func NewPokemonAPIRepository(httpClient *http.Client, config Config) repository.PokemonAPIRepository {
@@ -34,25 +45,241 @@ func NewPokemonAPIRepository(httpClient *http.Client, config Config) repository.
 		baseURL:    config.BaseURL,
 		httpClient: httpClient,
 		maxRetries: config.MaxRetries,
+		limiter:    newRateLimiter(config.RequestsPerSecond),
+		validators: newValidatorStore(),
 	}
 }
 
+// GetPokemon fetches a Pokemon, conditionally when it has been fetched
+// before. It returns repository.ErrNotModified when upstream reports that
+// the Pokemon has not changed since then.
 func (r *pokemonAPIRepository) GetPokemon(ctx context.Context, pokemonID int) (*entity.PokemonAPIResponse, error) {
-	url := fmt.Sprintf("%s/pokemon/%d", r.baseURL, pokemonID)
+	url := r.pokemonURL(pokemonID)
+
+	var pokemon *entity.PokemonAPIResponse
+	err := r.withRetry(ctx, func() error {
+		var err error
+		pokemon, err = r.fetchPokemon(ctx, url)
+		return err
+	})
+	if errors.Is(err, repository.ErrNotModified) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pokemon after %d retries: %w", r.maxRetries, err)
+	}
+
+	return pokemon, nil
+}
+
+func (r *pokemonAPIRepository) LookupPokemon(ctx context.Context, idOrName string) (*entity.PokemonAPIResponse, error) {
+	url := fmt.Sprintf("%s/pokemon/%s", r.baseURL, neturl.PathEscape(idOrName))
+
+	var pokemon entity.PokemonAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &pokemon)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pokemon after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &pokemon, nil
+}
+
+func (r *pokemonAPIRepository) ForgetPokemon(pokemonID int) {
+	r.validators.forget(r.pokemonURL(pokemonID))
+}
+
+func (r *pokemonAPIRepository) pokemonURL(pokemonID int) string {
+	return fmt.Sprintf("%s/pokemon/%d", r.baseURL, pokemonID)
+}
+
+func (r *pokemonAPIRepository) ListPokemon(ctx context.Context, limit, offset int) (*entity.PokemonListAPIResponse, error) {
+	url := fmt.Sprintf("%s/pokemon?limit=%d&offset=%d", r.baseURL, limit, offset)
+
+	var page entity.PokemonListAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &page)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pokemon after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &page, nil
+}
+
+func (r *pokemonAPIRepository) GetPokemonSpecies(ctx context.Context, speciesID int) (*entity.PokemonSpeciesAPIResponse, error) {
+	url := fmt.Sprintf("%s/pokemon-species/%d", r.baseURL, speciesID)
+
+	var species entity.PokemonSpeciesAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &species)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pokemon species after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &species, nil
+}
+
+func (r *pokemonAPIRepository) GetEvolutionChain(ctx context.Context, chainID int) (*entity.EvolutionChainAPIResponse, error) {
+	url := fmt.Sprintf("%s/evolution-chain/%d", r.baseURL, chainID)
+
+	var chain entity.EvolutionChainAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &chain)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch evolution chain after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &chain, nil
+}
+
+func (r *pokemonAPIRepository) GetMove(ctx context.Context, moveID int) (*entity.MoveAPIResponse, error) {
+	url := fmt.Sprintf("%s/move/%d", r.baseURL, moveID)
+
+	var move entity.MoveAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &move)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch move after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &move, nil
+}
+
+func (r *pokemonAPIRepository) GetAbility(ctx context.Context, abilityID int) (*entity.AbilityAPIResponse, error) {
+	url := fmt.Sprintf("%s/ability/%d", r.baseURL, abilityID)
+
+	var ability entity.AbilityAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &ability)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ability after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &ability, nil
+}
+
+// typeListLimit covers every upstream type; there are about twenty.
+const typeListLimit = 100
+
+func (r *pokemonAPIRepository) ListTypes(ctx context.Context) ([]entity.NamedAPIResource, error) {
+	url := fmt.Sprintf("%s/type?limit=%d", r.baseURL, typeListLimit)
+
+	var page entity.PokemonListAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &page)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list types after %d retries: %w", r.maxRetries, err)
+	}
 
+	return page.Results, nil
+}
+
+func (r *pokemonAPIRepository) GetType(ctx context.Context, name string) (*entity.TypeAPIResponse, error) {
+	url := fmt.Sprintf("%s/type/%s", r.baseURL, neturl.PathEscape(name))
+
+	var pokemonType entity.TypeAPIResponse
+	err := r.withRetry(ctx, func() error {
+		return r.fetchJSON(ctx, url, false, &pokemonType)
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch type after %d retries: %w", r.maxRetries, err)
+	}
+
+	return &pokemonType, nil
+}
+
+// DownloadSprite is not rate limited: sprites are served from a CDN rather
+// than the API the limit protects.
+func (r *pokemonAPIRepository) DownloadSprite(ctx context.Context, url string) ([]byte, error) {
+	var data []byte
+	err := r.withRetry(ctx, func() error {
+		var err error
+		data, err = r.fetchSprite(ctx, url)
+		return err
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download sprite after %d retries: %w", r.maxRetries, err)
+	}
+
+	return data, nil
+}
+
+func (r *pokemonAPIRepository) fetchSprite(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "github.com/AhmadNizar/cata-dtc/1.0")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, repository.ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSpriteBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading sprite: %w", err)
+	}
+	if len(data) > maxSpriteBytes {
+		return nil, fmt.Errorf("sprite exceeds %d bytes", maxSpriteBytes)
+	}
+
+	return data, nil
+}
+
+// withRetry runs fn until it succeeds, waiting a little longer before each
+// new attempt, and returns the last error once the retries are exhausted.
+func (r *pokemonAPIRepository) withRetry(ctx context.Context, fn func() error) error {
 	var lastErr error
 	for attempt := 0; attempt <= r.maxRetries; attempt++ {
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
-				return nil, ctx.Err()
+				return ctx.Err()
 			case <-time.After(time.Duration(attempt) * time.Second):
 			}
 		}
 
-		pokemon, err := r.fetchPokemon(ctx, url)
+		err := fn()
 		if err == nil {
-			return pokemon, nil
+			return nil
 		}
 
 		lastErr = err
@@ -61,39 +288,69 @@ func (r *pokemonAPIRepository) GetPokemon(ctx context.Context, pokemonID int) (*
 		}
 	}
 
-	return nil, fmt.Errorf("failed to fetch pokemon after %d retries: %w", r.maxRetries, lastErr)
+	return lastErr
 }
 
+// fetchPokemon sends the validators stored for url so that an unchanged
+// Pokemon comes back as a cheap 304 instead of a full body.
 func (r *pokemonAPIRepository) fetchPokemon(ctx context.Context, url string) (*entity.PokemonAPIResponse, error) {
+	var pokemon entity.PokemonAPIResponse
+	if err := r.fetchJSON(ctx, url, true, &pokemon); err != nil {
+		return nil, err
+	}
+
+	return &pokemon, nil
+}
+
+// fetchJSON decodes the resource at url into dest. When conditional is set
+// the request carries the validators from the previous response, and a 304
+// is reported as repository.ErrNotModified without touching dest.
+func (r *pokemonAPIRepository) fetchJSON(ctx context.Context, url string, conditional bool, dest interface{}) error {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "github.com/AhmadNizar/cata-dtc/1.0")
 	req.Header.Set("Accept", "application/json")
+	if conditional {
+		r.validators.apply(url, req)
+	}
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("making request: %w", err)
+		return fmt.Errorf("making request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if conditional && resp.StatusCode == http.StatusNotModified {
+		return repository.ErrNotModified
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return repository.ErrNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var pokemon entity.PokemonAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pokemon); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
 	}
 
-	return &pokemon, nil
+	if conditional {
+		r.validators.store(url, resp)
+	}
+
+	return nil
 }
 
 func (r *pokemonAPIRepository) shouldRetry(err error) bool {
-	if err == nil {
+	if err == nil || errors.Is(err, repository.ErrNotModified) || errors.Is(err, repository.ErrNotFound) {
 		return false
 	}
 	return true
-}
\ No newline at end of file
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterDisabled(t *testing.T) {
+	for _, rps := range []int{0, -1} {
+		limiter := newRateLimiter(rps)
+		if limiter != nil {
+			t.Fatalf("newRateLimiter(%d) = %v, want nil", rps, limiter)
+		}
+
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait: %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Errorf("nil limiter blocked for %v", elapsed)
+		}
+	}
+}
+
+func TestRateLimiterSpacesCalls(t *testing.T) {
+	tests := []struct {
+		name  string
+		rps   int
+		calls int
+	}{
+		{"first call is not delayed", 10, 1},
+		{"calls are spaced by the interval", 50, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := newRateLimiter(tt.rps)
+			interval := time.Second / time.Duration(tt.rps)
+
+			start := time.Now()
+			for i := 0; i < tt.calls; i++ {
+				if err := limiter.Wait(context.Background()); err != nil {
+					t.Fatalf("Wait: %v", err)
+				}
+			}
+			elapsed := time.Since(start)
+
+			want := time.Duration(tt.calls-1) * interval
+			if elapsed < want-5*time.Millisecond || elapsed > want+interval {
+				t.Errorf("%d calls took %v, want about %v", tt.calls, elapsed, want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	limiter := newRateLimiter(1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("canceled Wait returned after %v, want it to stop at the deadline", elapsed)
+	}
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+)
+
+// cacheValidators are the HTTP validators upstream returned for a resource.
+type cacheValidators struct {
+	etag         string
+	lastModified string
+}
+
+// validatorStore remembers the validators of every resource fetched so far,
+// keyed by URL, so that the next request for it can be made conditional.
+type validatorStore struct {
+	mu         sync.RWMutex
+	validators map[string]cacheValidators
+}
+
+func newValidatorStore() *validatorStore {
+	return &validatorStore{
+		validators: make(map[string]cacheValidators),
+	}
+}
+
+// apply adds If-None-Match / If-Modified-Since headers for url to req.
+func (s *validatorStore) apply(url string, req *http.Request) {
+	s.mu.RLock()
+	v, ok := s.validators[url]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	if v.etag != "" {
+		req.Header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		req.Header.Set("If-Modified-Since", v.lastModified)
+	}
+}
+
+// store keeps the validators of resp for url, if it carries any.
+func (s *validatorStore) store(url string, resp *http.Response) {
+	v := cacheValidators{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	if v.etag == "" && v.lastModified == "" {
+		return
+	}
+
+	s.mu.Lock()
+	s.validators[url] = v
+	s.mu.Unlock()
+}
+
+func (s *validatorStore) forget(url string) {
+	s.mu.Lock()
+	delete(s.validators, url)
+	s.mu.Unlock()
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLockHeld is returned when another owner currently holds the lock.
+var ErrLockHeld = errors.New("lock is held by another owner")
+
+// Lock is a lease on a cluster-wide lock. The lease is renewed in the
+// background until it is released or can no longer be renewed. Fencing
+// tokens for the holder are issued by FenceRepository.
+type Lock interface {
+	// Lost is closed when the lease expired or was taken over before it
+	// was released.
+	Lost() <-chan struct{}
+	Release(ctx context.Context) error
+}
+
+type LockRepository interface {
+	Acquire(ctx context.Context, name string, ttl time.Duration) (Lock, error)
+}
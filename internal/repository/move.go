@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+type MoveRepository interface {
+	GetByName(ctx context.Context, name string) (*entity.Move, error)
+	CreateOrUpdate(ctx context.Context, move *entity.Move) error
+	// ListStoredIDs returns those of ids that are stored.
+	ListStoredIDs(ctx context.Context, ids []uint) ([]uint, error)
+}
@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type evolutionChainRepository struct {
+	db *gorm.DB
+}
+
+func NewEvolutionChainRepository(db *gorm.DB) repository.EvolutionChainRepository {
+	return &evolutionChainRepository{
+		db: db,
+	}
+}
+
+// GetByIDWithLinks returns the chain with its links in upstream order,
+// parents before the species that evolve from them.
+func (r *evolutionChainRepository) GetByIDWithLinks(ctx context.Context, id uint) (*entity.EvolutionChain, error) {
+	var chain entity.EvolutionChain
+	query := r.db.WithContext(ctx).
+		Preload("Links", func(db *gorm.DB) *gorm.DB { return db.Order("id") })
+	err := preloadTranslations(ctx, query, "Links.Species").First(&chain, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting evolution chain by id: %w", err)
+	}
+	return &chain, nil
+}
+
+func (r *evolutionChainRepository) CreateOrUpdate(ctx context.Context, chain *entity.EvolutionChain) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Omit("Links").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		}).Create(chain).Error
+		if err != nil {
+			return fmt.Errorf("saving evolution chain: %w", err)
+		}
+
+		if err := tx.Where("chain_id = ?", chain.ID).Delete(&entity.EvolutionChainLink{}).Error; err != nil {
+			return fmt.Errorf("deleting existing evolution chain links: %w", err)
+		}
+
+		for i := range chain.Links {
+			chain.Links[i].ID = 0
+			chain.Links[i].ChainID = chain.ID
+		}
+		if len(chain.Links) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&chain.Links).Error; err != nil {
+				return fmt.Errorf("creating evolution chain links: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
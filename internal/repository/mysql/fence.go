@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type fenceRepository struct {
+	db *gorm.DB
+}
+
+func NewFenceRepository(db *gorm.DB) repository.FenceRepository {
+	return &fenceRepository{
+		db: db,
+	}
+}
+
+// Advance raises the fence with an exclusive row lock, so it waits for
+// in-flight fenced writes (which hold a shared lock on the same row) to
+// commit before any later write can observe the new token.
+func (r *fenceRepository) Advance(ctx context.Context, name string) (int64, error) {
+	var current entity.SyncFence
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"token": gorm.Expr("token + 1")}),
+		}).Create(&entity.SyncFence{Name: name, Token: 1}).Error
+		if err != nil {
+			return fmt.Errorf("advancing fence: %w", err)
+		}
+
+		if err := tx.Where("name = ?", name).First(&current).Error; err != nil {
+			return fmt.Errorf("reading fence: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return current.Token, nil
+}
+
+// checkFence rejects a write made under a fencing token older than the
+// current fence. It takes a shared lock on the fence row so the fence
+// cannot advance while the surrounding transaction is still writing.
+func checkFence(ctx context.Context, tx *gorm.DB) error {
+	name, token, ok := repository.FencingTokenFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var current entity.SyncFence
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("name = ?", name).First(&current).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading fence: %w", err)
+	}
+	if current.Token > token {
+		return repository.ErrStaleFencingToken
+	}
+
+	return nil
+}
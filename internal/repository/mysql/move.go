@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type moveRepository struct {
+	db *gorm.DB
+}
+
+func NewMoveRepository(db *gorm.DB) repository.MoveRepository {
+	return &moveRepository{
+		db: db,
+	}
+}
+
+func (r *moveRepository) GetByName(ctx context.Context, name string) (*entity.Move, error) {
+	var move entity.Move
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&move).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting move by name: %w", err)
+	}
+	return &move, nil
+}
+
+func (r *moveRepository) CreateOrUpdate(ctx context.Context, move *entity.Move) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "power", "accuracy", "pp", "damage_class", "type_name", "updated_at"}),
+		}).Create(move).Error
+		if err != nil {
+			return fmt.Errorf("saving move: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *moveRepository) ListStoredIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var stored []uint
+	if err := r.db.WithContext(ctx).Model(&entity.Move{}).Where("id IN ?", ids).Pluck("id", &stored).Error; err != nil {
+		return nil, fmt.Errorf("listing stored moves: %w", err)
+	}
+	return stored, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
 	"github.com/AhmadNizar/cata-dtc/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type pokemonRepository struct {
@@ -41,7 +43,8 @@ func (r *pokemonRepository) GetByID(ctx context.Context, id uint) (*entity.Pokem
 
 func (r *pokemonRepository) GetByIDWithRelations(ctx context.Context, id uint) (*entity.Pokemon, error) {
 	var pokemon entity.Pokemon
-	if err := r.db.WithContext(ctx).Preload("Types").Preload("Abilities").First(&pokemon, id).Error; err != nil {
+	query := r.db.WithContext(ctx).Scopes(preloadPokemonRelations)
+	if err := preloadPokemonTranslations(ctx, query).First(&pokemon, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -63,7 +66,8 @@ func (r *pokemonRepository) GetByName(ctx context.Context, name string) (*entity
 
 func (r *pokemonRepository) GetByNameWithRelations(ctx context.Context, name string) (*entity.Pokemon, error) {
 	var pokemon entity.Pokemon
-	if err := r.db.WithContext(ctx).Preload("Types").Preload("Abilities").Where("name = ?", name).First(&pokemon).Error; err != nil {
+	query := r.db.WithContext(ctx).Scopes(preloadPokemonRelations)
+	if err := preloadPokemonTranslations(ctx, query).Where("name = ?", name).First(&pokemon).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -72,9 +76,9 @@ func (r *pokemonRepository) GetByNameWithRelations(ctx context.Context, name str
 	return &pokemon, nil
 }
 
-func (r *pokemonRepository) List(ctx context.Context, limit, offset int) ([]*entity.Pokemon, error) {
+func (r *pokemonRepository) List(ctx context.Context, order repository.PokemonSort, limit, offset int) ([]*entity.Pokemon, error) {
 	var pokemons []*entity.Pokemon
-	query := r.db.WithContext(ctx).Order("id ASC")
+	query := r.db.WithContext(ctx).Scopes(orderPokemon(order))
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -90,9 +94,10 @@ func (r *pokemonRepository) List(ctx context.Context, limit, offset int) ([]*ent
 	return pokemons, nil
 }
 
-func (r *pokemonRepository) ListWithRelations(ctx context.Context, limit, offset int) ([]*entity.Pokemon, error) {
+func (r *pokemonRepository) ListWithRelations(ctx context.Context, filter repository.PokemonFilter, order repository.PokemonSort, limit, offset int) ([]*entity.Pokemon, error) {
 	var pokemons []*entity.Pokemon
-	query := r.db.WithContext(ctx).Preload("Types").Preload("Abilities").Order("id ASC")
+	query := applyPokemonFilter(r.db.WithContext(ctx).Scopes(preloadPokemonRelations), filter).Scopes(orderPokemon(order))
+	query = preloadPokemonTranslations(ctx, query)
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -108,6 +113,123 @@ func (r *pokemonRepository) ListWithRelations(ctx context.Context, limit, offset
 	return pokemons, nil
 }
 
+func (r *pokemonRepository) ListWithRelationsAfter(ctx context.Context, filter repository.PokemonFilter, order repository.PokemonSort, after repository.PokemonCursor, limit int) ([]*entity.Pokemon, error) {
+	var pokemons []*entity.Pokemon
+	query := applyPokemonFilter(r.db.WithContext(ctx).Scopes(preloadPokemonRelations), filter).Scopes(afterPokemonCursor(order, after), orderPokemon(order))
+	query = preloadPokemonTranslations(ctx, query)
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&pokemons).Error; err != nil {
+		return nil, fmt.Errorf("listing pokemons with relations after cursor: %w", err)
+	}
+
+	return pokemons, nil
+}
+
+// pokemonSortColumns maps repository.PokemonSortFields to their columns.
+var pokemonSortColumns = map[string]string{
+	"id":              "pokemon.id",
+	"name":            "pokemon.name",
+	"height":          "pokemon.height",
+	"weight":          "pokemon.weight",
+	"base_experience": "pokemon.base_experience",
+	"order":           "pokemon.order_num",
+}
+
+// sortKey is one column of the total order of a sorted list, with the
+// value it has in a cursor.
+type sortKey struct {
+	column string
+	desc   bool
+	value  interface{}
+}
+
+// pokemonSortKeys expands order into the columns that order the list,
+// adding the ID when order does not already end ties on it. Values are
+// taken from after when it is given.
+func pokemonSortKeys(order repository.PokemonSort, after *repository.PokemonCursor) ([]sortKey, error) {
+	if after != nil && len(after.Values) != len(order) {
+		return nil, fmt.Errorf("cursor has %d sort values, want %d", len(after.Values), len(order))
+	}
+
+	keys := make([]sortKey, 0, len(order)+1)
+	for i, field := range order {
+		column, ok := pokemonSortColumns[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown pokemon sort field %q", field.Name)
+		}
+		key := sortKey{column: column, desc: field.Desc}
+		if after != nil {
+			key.value = after.Values[i]
+		}
+		keys = append(keys, key)
+
+		if field.Name == "id" {
+			// IDs are unique, so later fields never break a tie
+			return keys, nil
+		}
+	}
+
+	key := sortKey{column: "pokemon.id"}
+	if after != nil {
+		key.value = after.ID
+	}
+	return append(keys, key), nil
+}
+
+// orderPokemon orders a query by order, then by ID.
+func orderPokemon(order repository.PokemonSort) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		keys, err := pokemonSortKeys(order, nil)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		for _, key := range keys {
+			direction := "ASC"
+			if key.desc {
+				direction = "DESC"
+			}
+			db = db.Order(key.column + " " + direction)
+		}
+		return db
+	}
+}
+
+// afterPokemonCursor selects the Pokemon that come after the cursor in the
+// order of orderPokemon(order): those past it on the first sort column, or
+// tied on it and past it on the next, and so on down to the ID.
+func afterPokemonCursor(order repository.PokemonSort, after repository.PokemonCursor) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		keys, err := pokemonSortKeys(order, &after)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+
+		var clauses []string
+		var args []interface{}
+		for i, key := range keys {
+			var conditions []string
+			for _, tied := range keys[:i] {
+				conditions = append(conditions, tied.column+" = ?")
+				args = append(args, tied.value)
+			}
+			operator := ">"
+			if key.desc {
+				operator = "<"
+			}
+			conditions = append(conditions, key.column+" "+operator+" ?")
+			args = append(args, key.value)
+			clauses = append(clauses, "("+strings.Join(conditions, " AND ")+")")
+		}
+		return db.Where(strings.Join(clauses, " OR "), args...)
+	}
+}
+
 func (r *pokemonRepository) Update(ctx context.Context, pokemon *entity.Pokemon) error {
 	if err := r.db.WithContext(ctx).Save(pokemon).Error; err != nil {
 		return fmt.Errorf("updating pokemon: %w", err)
@@ -122,112 +244,560 @@ func (r *pokemonRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (r *pokemonRepository) Count(ctx context.Context) (int64, error) {
+func (r *pokemonRepository) Count(ctx context.Context, filter repository.PokemonFilter) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&entity.Pokemon{}).Count(&count).Error; err != nil {
+	if err := applyPokemonFilter(r.db.WithContext(ctx).Model(&entity.Pokemon{}), filter).Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("counting pokemons: %w", err)
 	}
 	return count, nil
 }
 
-func (r *pokemonRepository) CreateOrUpdate(ctx context.Context, pokemon *entity.Pokemon) error {
+// applyPokemonFilter narrows query to the Pokemon selected by filter. A
+// form name selects the Pokemon with it whether they are the default
+// variety or not. Types and abilities are matched by semi-joins through
+// the indexed link tables to the unique type and ability names.
+func applyPokemonFilter(query *gorm.DB, filter repository.PokemonFilter) *gorm.DB {
+	switch {
+	case filter.Form != "":
+		query = query.Where("pokemon.form_name = ?", filter.Form)
+	case !filter.IncludeForms:
+		query = query.Where("pokemon.is_default = ?", true)
+	}
+
+	if len(filter.Types) > 0 {
+		withTypes := query.Session(&gorm.Session{NewDB: true}).
+			Table("pokemon_type").
+			Select("pokemon_type.pokemon_id").
+			Joins("JOIN `type` ON `type`.id = pokemon_type.type_id").
+			Where("`type`.name IN ?", filter.Types)
+		if filter.AllTypes {
+			withTypes = withTypes.
+				Group("pokemon_type.pokemon_id").
+				Having("COUNT(DISTINCT pokemon_type.type_id) = ?", len(filter.Types))
+		}
+		query = query.Where("pokemon.id IN (?)", withTypes)
+	}
+
+	if filter.Ability != "" || filter.HiddenAbility != nil {
+		withAbility := query.Session(&gorm.Session{NewDB: true}).
+			Table("pokemon_ability").
+			Select("pokemon_ability.pokemon_id")
+		if filter.Ability != "" {
+			withAbility = withAbility.
+				Joins("JOIN ability ON ability.id = pokemon_ability.ability_id").
+				Where("ability.name = ?", filter.Ability)
+		}
+		if filter.HiddenAbility != nil {
+			withAbility = withAbility.Where("pokemon_ability.is_hidden = ?", *filter.HiddenAbility)
+		}
+		query = query.Where("pokemon.id IN (?)", withAbility)
+	}
+
+	query = applyIntRange(query, "pokemon.height", filter.Height)
+	query = applyIntRange(query, "pokemon.weight", filter.Weight)
+	query = applyIntRange(query, "pokemon.base_experience", filter.BaseExp)
+	query = applyIntRange(query, "pokemon.order_num", filter.Order)
+	return query
+}
+
+func applyIntRange(query *gorm.DB, column string, bounds repository.IntRange) *gorm.DB {
+	if bounds.Min != nil {
+		query = query.Where(column+" >= ?", *bounds.Min)
+	}
+	if bounds.Max != nil {
+		query = query.Where(column+" <= ?", *bounds.Max)
+	}
+	return query
+}
+
+func (r *pokemonRepository) ListVarietyIDs(ctx context.Context, pokemonIDs []uint) ([]uint, error) {
+	var ids []uint
+	if len(pokemonIDs) == 0 {
+		return ids, nil
+	}
+
+	species := r.db.Model(&entity.Pokemon{}).Select("species_id").Where("id IN ?", pokemonIDs)
+	if err := r.db.WithContext(ctx).Model(&entity.Pokemon{}).
+		Where("is_default = ? AND species_id IN (?)", false, species).
+		Order("id ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("listing pokemon variety ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *pokemonRepository) TombstoneMissing(ctx context.Context, listedIDs []uint) ([]*entity.Pokemon, error) {
+	if len(listedIDs) == 0 {
+		return nil, repository.ErrNoListedPokemon
+	}
+
+	var missing []*entity.Pokemon
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := tx.Select("id", "name").Where("id NOT IN ?", listedIDs).Order("id ASC").Find(&missing).Error; err != nil {
+			return fmt.Errorf("finding missing pokemons: %w", err)
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(missing))
+		for i, pokemon := range missing {
+			ids[i] = pokemon.ID
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&entity.Pokemon{}).Error; err != nil {
+			return fmt.Errorf("tombstoning pokemons: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+func (r *pokemonRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entity.Pokemon, error) {
+	var pokemons []*entity.Pokemon
+	query := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&pokemons).Error; err != nil {
+		return nil, fmt.Errorf("listing deleted pokemons: %w", err)
+	}
+
+	return pokemons, nil
+}
+
+func (r *pokemonRepository) CountDeleted(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&entity.Pokemon{}).Where("deleted_at IS NOT NULL").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("counting deleted pokemons: %w", err)
+	}
+	return count, nil
+}
+
+func (r *pokemonRepository) Restore(ctx context.Context, id uint) (*entity.Pokemon, error) {
+	var restored *entity.Pokemon
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		var pokemon entity.Pokemon
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&pokemon, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return fmt.Errorf("getting deleted pokemon: %w", err)
+		}
+
+		if err := tx.Unscoped().Model(&pokemon).Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("restoring pokemon: %w", err)
+		}
+
+		pokemon.DeletedAt = gorm.DeletedAt{}
+		restored = &pokemon
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (r *pokemonRepository) CreateOrUpdate(ctx context.Context, pokemon *entity.Pokemon) (entity.UpsertResult, error) {
+	var result entity.UpsertResult
+
 	// Use transaction to ensure atomicity and idempotency
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		existing, err := r.getByNameInTx(ctx, tx, pokemon.Name)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		existing, err := r.getExistingInTx(tx, pokemon)
 		if err != nil {
 			return fmt.Errorf("checking existing pokemon: %w", err)
 		}
 
+		var diff entity.PokemonDiff
 		if existing != nil {
 			// Check if data actually changed to avoid unnecessary updates
-			if r.isDataUnchanged(existing, pokemon) {
+			diff = entity.DiffPokemon(existing, pokemon)
+			if !existing.DeletedAt.Valid && diff.IsEmpty() {
 				log.Printf("⚡ SQL SKIP: Pokemon ID %d (%s) unchanged, skipping update", existing.ID, existing.Name)
+				result = entity.UpsertResultUnchanged
 				return nil
 			}
 
 			pokemon.ID = existing.ID
 			pokemon.CreatedAt = existing.CreatedAt
+		}
 
-			// Delete existing types and abilities first
-			if err := tx.Where("pokemon_id = ?", pokemon.ID).Delete(&entity.PokemonType{}).Error; err != nil {
-				return fmt.Errorf("deleting existing pokemon types: %w", err)
+		if err := resolveTypeIDs(tx, pokemon.Types); err != nil {
+			return err
+		}
+		if err := resolveAbilityIDs(tx, pokemon.Abilities); err != nil {
+			return err
+		}
+
+		if existing != nil {
+			// Relationships are written below, not by GORM's association
+			// saving. Saving a tombstoned Pokemon clears its deleted_at.
+			if err := tx.Unscoped().Omit(clause.Associations).Save(pokemon).Error; err != nil {
+				return fmt.Errorf("updating pokemon: %w", err)
 			}
-			if err := tx.Where("pokemon_id = ?", pokemon.ID).Delete(&entity.PokemonAbility{}).Error; err != nil {
-				return fmt.Errorf("deleting existing pokemon abilities: %w", err)
+
+			if err := reconcilePokemonTypes(tx, pokemon.ID, existing.Types, pokemon.Types); err != nil {
+				return err
+			}
+			if err := reconcilePokemonAbilities(tx, pokemon.ID, existing.Abilities, pokemon.Abilities); err != nil {
+				return err
 			}
 
-			// Update the pokemon with new relationships
-			if err := tx.Save(pokemon).Error; err != nil {
-				return fmt.Errorf("updating pokemon with relationships: %w", err)
+			// Delete existing stats and moves first
+			if err := tx.Where("pokemon_id = ?", pokemon.ID).Delete(&entity.PokemonStat{}).Error; err != nil {
+				return fmt.Errorf("deleting existing pokemon stats: %w", err)
+			}
+			if err := tx.Where("pokemon_id = ?", pokemon.ID).Delete(&entity.PokemonMove{}).Error; err != nil {
+				return fmt.Errorf("deleting existing pokemon moves: %w", err)
+			}
+			if err := createPokemonRelations(tx, pokemon, false); err != nil {
+				return err
+			}
+			if err := recordPokemonHistory(ctx, tx, existing, pokemon, diff); err != nil {
+				return err
 			}
 
-			log.Printf("✅ SQL UPDATE SUCCESS: Pokemon ID %d (%s) updated with %d types and %d abilities",
-				pokemon.ID, pokemon.Name, len(pokemon.Types), len(pokemon.Abilities))
+			if existing.DeletedAt.Valid {
+				log.Printf("♻️ Pokemon ID %d (%s) is back upstream, restoring it", pokemon.ID, pokemon.Name)
+			}
+			log.Printf("✅ SQL UPDATE SUCCESS: Pokemon ID %d (%s) updated with %d types, %d abilities, %d stats and %d moves",
+				pokemon.ID, pokemon.Name, len(pokemon.Types), len(pokemon.Abilities), len(pokemon.Stats), len(pokemon.Moves))
+			result = entity.UpsertResultUpdated
 			return nil
 		}
 
-		// Create new pokemon - use ON CONFLICT for extra safety
-		if err := tx.Create(pokemon).Error; err != nil {
-			return fmt.Errorf("creating pokemon with relationships: %w", err)
+		// Create new pokemon
+		if err := tx.Omit(clause.Associations).Create(pokemon).Error; err != nil {
+			return fmt.Errorf("creating pokemon: %w", err)
+		}
+		if err := createPokemonRelations(tx, pokemon, true); err != nil {
+			return err
 		}
 
-		log.Printf("✅ SQL CREATE SUCCESS: Pokemon ID %d (%s) created with %d types and %d abilities",
-			pokemon.ID, pokemon.Name, len(pokemon.Types), len(pokemon.Abilities))
+		log.Printf("✅ SQL CREATE SUCCESS: Pokemon ID %d (%s) created with %d types, %d abilities, %d stats and %d moves",
+			pokemon.ID, pokemon.Name, len(pokemon.Types), len(pokemon.Abilities), len(pokemon.Stats), len(pokemon.Moves))
+		result = entity.UpsertResultCreated
 		return nil
 	})
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
 }
 
-// Helper function to get pokemon by name within a transaction
-func (r *pokemonRepository) getByNameInTx(ctx context.Context, tx *gorm.DB, name string) (*entity.Pokemon, error) {
-	var pokemon entity.Pokemon
-	if err := tx.Preload("Types").Preload("Abilities").Where("name = ?", name).First(&pokemon).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
+// createPokemonRelations inserts the stats and moves of a Pokemon, and its
+// types and abilities too when withLinks is set.
+func createPokemonRelations(tx *gorm.DB, pokemon *entity.Pokemon, withLinks bool) error {
+	if withLinks {
+		for i := range pokemon.Types {
+			pokemon.Types[i].PokemonID = pokemon.ID
+		}
+		if err := createRows(tx, pokemon.Types); err != nil {
+			return fmt.Errorf("creating pokemon types: %w", err)
+		}
+
+		for i := range pokemon.Abilities {
+			pokemon.Abilities[i].PokemonID = pokemon.ID
+		}
+		if err := createRows(tx, pokemon.Abilities); err != nil {
+			return fmt.Errorf("creating pokemon abilities: %w", err)
 		}
-		return nil, fmt.Errorf("getting pokemon by name in transaction: %w", err)
 	}
-	return &pokemon, nil
+
+	for i := range pokemon.Stats {
+		pokemon.Stats[i].ID = 0
+		pokemon.Stats[i].PokemonID = pokemon.ID
+	}
+	if err := createRows(tx, pokemon.Stats); err != nil {
+		return fmt.Errorf("creating pokemon stats: %w", err)
+	}
+
+	for i := range pokemon.Moves {
+		pokemon.Moves[i].ID = 0
+		pokemon.Moves[i].PokemonID = pokemon.ID
+	}
+	if err := createRows(tx, pokemon.Moves); err != nil {
+		return fmt.Errorf("creating pokemon moves: %w", err)
+	}
+
+	return nil
+}
+
+// preloadPokemonTranslations loads the localized names of a Pokemon's
+// species, types and abilities.
+func preloadPokemonTranslations(ctx context.Context, query *gorm.DB) *gorm.DB {
+	return preloadTranslations(ctx, query, "Species", "Types.Type", "Abilities.Ability")
+}
+
+// createRows batch-inserts rows without touching the records they refer to.
+func createRows[T any](tx *gorm.DB, rows []T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Create(&rows).Error
+}
+
+// resolveTypeIDs makes sure every type named by pokemonTypes exists in the
+// master table and fills in its ID.
+func resolveTypeIDs(tx *gorm.DB, pokemonTypes []entity.PokemonType) error {
+	names := make([]string, len(pokemonTypes))
+	for i, pokemonType := range pokemonTypes {
+		names[i] = pokemonType.Type.Name
+	}
+
+	ids, err := resolveMasterIDs(tx, &entity.Type{}, names, func(name string) interface{} {
+		return &entity.Type{Name: name}
+	})
+	if err != nil {
+		return fmt.Errorf("resolving types: %w", err)
+	}
+
+	for i := range pokemonTypes {
+		pokemonTypes[i].TypeID = ids[pokemonTypes[i].Type.Name]
+		pokemonTypes[i].Type.ID = pokemonTypes[i].TypeID
+	}
+	return nil
+}
+
+// resolveAbilityIDs is resolveTypeIDs for abilities.
+func resolveAbilityIDs(tx *gorm.DB, pokemonAbilities []entity.PokemonAbility) error {
+	names := make([]string, len(pokemonAbilities))
+	for i, pokemonAbility := range pokemonAbilities {
+		names[i] = pokemonAbility.Ability.Name
+	}
+
+	ids, err := resolveMasterIDs(tx, &entity.Ability{}, names, func(name string) interface{} {
+		return &entity.Ability{Name: name}
+	})
+	if err != nil {
+		return fmt.Errorf("resolving abilities: %w", err)
+	}
+
+	for i := range pokemonAbilities {
+		pokemonAbilities[i].AbilityID = ids[pokemonAbilities[i].Ability.Name]
+		pokemonAbilities[i].Ability.ID = pokemonAbilities[i].AbilityID
+	}
+	return nil
+}
+
+// resolveMasterIDs returns the IDs of names in a master table with a unique
+// name column, inserting the names it does not have yet.
+func resolveMasterIDs(tx *gorm.DB, model interface{}, names []string, newRow func(name string) interface{}) (map[string]uint, error) {
+	ids := make(map[string]uint, len(names))
+	if len(names) == 0 {
+		return ids, nil
+	}
+
+	lookup := func() error {
+		var rows []struct {
+			ID   uint
+			Name string
+		}
+		if err := tx.Model(model).Select("id", "name").Where("name IN ?", names).Find(&rows).Error; err != nil {
+			return fmt.Errorf("looking up ids: %w", err)
+		}
+		for _, row := range rows {
+			ids[row.Name] = row.ID
+		}
+		return nil
+	}
+
+	if err := lookup(); err != nil {
+		return nil, err
+	}
+
+	missing := false
+	for _, name := range names {
+		if ids[name] != 0 {
+			continue
+		}
+		missing = true
+
+		// Another writer may insert the same name concurrently
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(newRow(name)).Error; err != nil {
+			return nil, fmt.Errorf("inserting %q: %w", name, err)
+		}
+	}
+	if !missing {
+		return ids, nil
+	}
+
+	if err := lookup(); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if ids[name] == 0 {
+			return nil, fmt.Errorf("no id for %q", name)
+		}
+	}
+	return ids, nil
+}
+
+// reconcilePokemonTypes brings the type links of a Pokemon in line with
+// desired, deleting and inserting only the links that differ and moving
+// kept links to their new slot.
+func reconcilePokemonTypes(tx *gorm.DB, pokemonID uint, existing, desired []entity.PokemonType) error {
+	current := make(map[uint]entity.PokemonType, len(existing))
+	for _, pokemonType := range existing {
+		current[pokemonType.TypeID] = pokemonType
+	}
+
+	var added []entity.PokemonType
+	for _, pokemonType := range desired {
+		if kept, ok := current[pokemonType.TypeID]; ok {
+			delete(current, pokemonType.TypeID)
+			if err := updateSlot(tx, &entity.PokemonType{}, kept.ID, kept.Slot, pokemonType.Slot); err != nil {
+				return fmt.Errorf("updating pokemon type slot: %w", err)
+			}
+			continue
+		}
+		pokemonType.PokemonID = pokemonID
+		added = append(added, pokemonType)
+	}
+
+	for _, stale := range current {
+		if err := tx.Delete(&entity.PokemonType{}, stale.ID).Error; err != nil {
+			return fmt.Errorf("deleting pokemon type: %w", err)
+		}
+	}
+	if err := createRows(tx, added); err != nil {
+		return fmt.Errorf("creating pokemon types: %w", err)
+	}
+
+	return nil
 }
 
-// Helper function to check if Pokemon data has actually changed
-func (r *pokemonRepository) isDataUnchanged(existing, new *entity.Pokemon) bool {
-	// Compare basic fields
-	if existing.Height != new.Height ||
-		existing.Weight != new.Weight ||
-		existing.BaseExp != new.BaseExp ||
-		existing.OrderNum != new.OrderNum {
-		return false
+// reconcilePokemonAbilities is reconcilePokemonTypes for abilities, where a
+// link is identified by the ability and whether it is hidden.
+func reconcilePokemonAbilities(tx *gorm.DB, pokemonID uint, existing, desired []entity.PokemonAbility) error {
+	type linkKey struct {
+		abilityID uint
+		isHidden  bool
 	}
 
-	// Compare types
-	if len(existing.Types) != len(new.Types) {
-		return false
+	current := make(map[linkKey]entity.PokemonAbility, len(existing))
+	for _, pokemonAbility := range existing {
+		current[linkKey{pokemonAbility.AbilityID, pokemonAbility.IsHidden}] = pokemonAbility
 	}
-	existingTypes := make(map[string]bool)
-	for _, t := range existing.Types {
-		existingTypes[t.TypeName] = true
+
+	var added []entity.PokemonAbility
+	for _, pokemonAbility := range desired {
+		key := linkKey{pokemonAbility.AbilityID, pokemonAbility.IsHidden}
+		if kept, ok := current[key]; ok {
+			delete(current, key)
+			if err := updateSlot(tx, &entity.PokemonAbility{}, kept.ID, kept.Slot, pokemonAbility.Slot); err != nil {
+				return fmt.Errorf("updating pokemon ability slot: %w", err)
+			}
+			continue
+		}
+		pokemonAbility.PokemonID = pokemonID
+		added = append(added, pokemonAbility)
 	}
-	for _, t := range new.Types {
-		if !existingTypes[t.TypeName] {
-			return false
+
+	for _, stale := range current {
+		if err := tx.Delete(&entity.PokemonAbility{}, stale.ID).Error; err != nil {
+			return fmt.Errorf("deleting pokemon ability: %w", err)
 		}
 	}
+	if err := createRows(tx, added); err != nil {
+		return fmt.Errorf("creating pokemon abilities: %w", err)
+	}
+
+	return nil
+}
 
-	// Compare abilities
-	if len(existing.Abilities) != len(new.Abilities) {
-		return false
+// updateSlot moves a kept type or ability link to its new slot.
+func updateSlot(tx *gorm.DB, model interface{}, id uint, old, new int) error {
+	if old == new {
+		return nil
 	}
-	existingAbilities := make(map[string]bool)
-	for _, a := range existing.Abilities {
-		key := fmt.Sprintf("%s_%t", a.AbilityName, a.IsHidden)
-		existingAbilities[key] = true
+	return tx.Model(model).Where("id = ?", id).Update("slot", new).Error
+}
+
+// preloadPokemonRelations loads the types, abilities and stats of Pokemon,
+// with types and abilities in slot order.
+func preloadPokemonRelations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Types", orderBySlot).Preload("Types.Type").
+		Preload("Abilities", orderBySlot).Preload("Abilities.Ability").
+		Preload("Stats")
+}
+
+func orderBySlot(db *gorm.DB) *gorm.DB {
+	return db.Order("slot ASC").Order("id ASC")
+}
+
+// getExistingInTx returns the stored version of pokemon, tombstoned or not,
+// within a transaction. It matches by ID first, so a Pokemon renamed
+// upstream updates its row, and by name for a Pokemon without an ID.
+func (r *pokemonRepository) getExistingInTx(tx *gorm.DB, pokemon *entity.Pokemon) (*entity.Pokemon, error) {
+	if pokemon.ID != 0 {
+		existing, err := getPokemonInTx(tx, "id = ?", pokemon.ID)
+		if existing != nil || err != nil {
+			return existing, err
+		}
 	}
-	for _, a := range new.Abilities {
-		key := fmt.Sprintf("%s_%t", a.AbilityName, a.IsHidden)
-		if !existingAbilities[key] {
-			return false
+	return getPokemonInTx(tx, "name = ?", pokemon.Name)
+}
+
+func getPokemonInTx(tx *gorm.DB, query string, arg interface{}) (*entity.Pokemon, error) {
+	var pokemon entity.Pokemon
+	if err := tx.Unscoped().Scopes(preloadPokemonRelations).Preload("Moves").Where(query, arg).First(&pokemon).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("getting pokemon in transaction: %w", err)
+	}
+	return &pokemon, nil
+}
+
+// recordPokemonHistory appends the next version to the change log of a
+// Pokemon. It runs in the transaction of the update it records, so the log
+// never misses or invents an update. The update has already locked the
+// Pokemon row, so concurrent updates cannot take the same version.
+func recordPokemonHistory(ctx context.Context, tx *gorm.DB, existing, updated *entity.Pokemon, diff entity.PokemonDiff) error {
+	var latest int
+	if err := tx.Model(&entity.PokemonHistory{}).
+		Where("pokemon_id = ?", updated.ID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error; err != nil {
+		return fmt.Errorf("getting latest pokemon history version: %w", err)
 	}
 
-	return true
+	history := &entity.PokemonHistory{
+		PokemonID: updated.ID,
+		Version:   latest + 1,
+		Previous:  entity.NewPokemonSnapshot(existing),
+		Current:   entity.NewPokemonSnapshot(updated),
+		Diff:      diff,
+	}
+	if id, ok := repository.SyncRunIDFromContext(ctx); ok {
+		history.SyncRunID = &id
+	}
+	if err := tx.Create(history).Error; err != nil {
+		return fmt.Errorf("recording pokemon history: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,212 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// dryRunDB builds statements without connecting to a database.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "user@tcp(127.0.0.1:3306)/test", SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("opening dry run database: %v", err)
+	}
+	return db
+}
+
+func TestAfterPokemonCursor(t *testing.T) {
+	tests := []struct {
+		name      string
+		order     repository.PokemonSort
+		after     repository.PokemonCursor
+		wantWhere string
+		wantVars  []interface{}
+	}{
+		{
+			name:      "default sort is by id",
+			after:     repository.PokemonCursor{ID: 7},
+			wantWhere: "(pokemon.id > ?)",
+			wantVars:  []interface{}{uint(7)},
+		},
+		{
+			name:      "id descending needs no tie-break",
+			order:     repository.PokemonSort{{Name: "id", Desc: true}},
+			after:     repository.PokemonCursor{ID: 7, Values: []interface{}{int64(7)}},
+			wantWhere: "(pokemon.id < ?)",
+			wantVars:  []interface{}{int64(7)},
+		},
+		{
+			name:      "string key is broken by id",
+			order:     repository.PokemonSort{{Name: "name"}},
+			after:     repository.PokemonCursor{ID: 25, Values: []interface{}{"pikachu"}},
+			wantWhere: "((pokemon.name > ?) OR (pokemon.name = ? AND pokemon.id > ?))",
+			wantVars:  []interface{}{"pikachu", "pikachu", uint(25)},
+		},
+		{
+			name:      "descending string key",
+			order:     repository.PokemonSort{{Name: "name", Desc: true}},
+			after:     repository.PokemonCursor{ID: 25, Values: []interface{}{"pikachu"}},
+			wantWhere: "((pokemon.name < ?) OR (pokemon.name = ? AND pokemon.id > ?))",
+			wantVars:  []interface{}{"pikachu", "pikachu", uint(25)},
+		},
+		{
+			name:  "mixed directions",
+			order: repository.PokemonSort{{Name: "base_experience", Desc: true}, {Name: "name"}},
+			after: repository.PokemonCursor{ID: 25, Values: []interface{}{int64(112), "pikachu"}},
+			wantWhere: "((pokemon.base_experience < ?)" +
+				" OR (pokemon.base_experience = ? AND pokemon.name > ?)" +
+				" OR (pokemon.base_experience = ? AND pokemon.name = ? AND pokemon.id > ?))",
+			wantVars: []interface{}{int64(112), int64(112), "pikachu", int64(112), "pikachu", uint(25)},
+		},
+		{
+			name:  "descending numeric keys",
+			order: repository.PokemonSort{{Name: "height", Desc: true}, {Name: "weight", Desc: true}},
+			after: repository.PokemonCursor{ID: 25, Values: []interface{}{int64(4), int64(60)}},
+			wantWhere: "((pokemon.height < ?)" +
+				" OR (pokemon.height = ? AND pokemon.weight < ?)" +
+				" OR (pokemon.height = ? AND pokemon.weight = ? AND pokemon.id > ?))",
+			wantVars: []interface{}{int64(4), int64(4), int64(60), int64(4), int64(60), uint(25)},
+		},
+		{
+			name:      "order field maps to its column",
+			order:     repository.PokemonSort{{Name: "order"}},
+			after:     repository.PokemonCursor{ID: 25, Values: []interface{}{int64(35)}},
+			wantWhere: "((pokemon.order_num > ?) OR (pokemon.order_num = ? AND pokemon.id > ?))",
+			wantVars:  []interface{}{int64(35), int64(35), uint(25)},
+		},
+		{
+			name:      "id in the sort ends the tie-break",
+			order:     repository.PokemonSort{{Name: "height"}, {Name: "id", Desc: true}, {Name: "name"}},
+			after:     repository.PokemonCursor{ID: 25, Values: []interface{}{int64(4), int64(25), "pikachu"}},
+			wantWhere: "((pokemon.height > ?) OR (pokemon.height = ? AND pokemon.id < ?))",
+			wantVars:  []interface{}{int64(4), int64(4), int64(25)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pokemons []*entity.Pokemon
+			stmt := dryRunDB(t).Scopes(afterPokemonCursor(tt.order, tt.after)).Find(&pokemons).Statement
+			if stmt.Error != nil {
+				t.Fatalf("building query: %v", stmt.Error)
+			}
+
+			wantSQL := "SELECT * FROM `pokemon` WHERE " + tt.wantWhere + " AND `pokemon`.`deleted_at` IS NULL"
+			if got := stmt.SQL.String(); got != wantSQL {
+				t.Errorf("SQL = %s\nwant  %s", got, wantSQL)
+			}
+			if !reflect.DeepEqual(stmt.Vars, tt.wantVars) {
+				t.Errorf("vars = %#v, want %#v", stmt.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestOrderPokemon(t *testing.T) {
+	tests := []struct {
+		name      string
+		order     repository.PokemonSort
+		wantOrder string
+	}{
+		{
+			name:      "default sort is by id",
+			wantOrder: "pokemon.id ASC",
+		},
+		{
+			name:      "ties are broken by id",
+			order:     repository.PokemonSort{{Name: "base_experience", Desc: true}, {Name: "name"}},
+			wantOrder: "pokemon.base_experience DESC,pokemon.name ASC,pokemon.id ASC",
+		},
+		{
+			name:      "order field maps to its column",
+			order:     repository.PokemonSort{{Name: "order", Desc: true}},
+			wantOrder: "pokemon.order_num DESC,pokemon.id ASC",
+		},
+		{
+			name:      "id in the sort ends the keys",
+			order:     repository.PokemonSort{{Name: "height"}, {Name: "id", Desc: true}, {Name: "name"}},
+			wantOrder: "pokemon.height ASC,pokemon.id DESC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pokemons []*entity.Pokemon
+			stmt := dryRunDB(t).Scopes(orderPokemon(tt.order)).Find(&pokemons).Statement
+			if stmt.Error != nil {
+				t.Fatalf("building query: %v", stmt.Error)
+			}
+
+			wantSQL := "SELECT * FROM `pokemon` WHERE `pokemon`.`deleted_at` IS NULL ORDER BY " + tt.wantOrder
+			if got := stmt.SQL.String(); got != wantSQL {
+				t.Errorf("SQL = %s\nwant  %s", got, wantSQL)
+			}
+		})
+	}
+}
+
+func TestOrderPokemonRejectsUnknownField(t *testing.T) {
+	var pokemons []*entity.Pokemon
+	order := repository.PokemonSort{{Name: "color"}}
+	if err := dryRunDB(t).Scopes(orderPokemon(order)).Find(&pokemons).Error; err == nil {
+		t.Error("expected an error, got nil")
+	}
+}
+
+func TestAfterPokemonCursorRejectsMismatchedCursor(t *testing.T) {
+	tests := []struct {
+		name  string
+		order repository.PokemonSort
+		after repository.PokemonCursor
+	}{
+		{
+			name:  "missing sort value",
+			order: repository.PokemonSort{{Name: "height"}},
+			after: repository.PokemonCursor{ID: 25},
+		},
+		{
+			name:  "extra sort value",
+			after: repository.PokemonCursor{ID: 25, Values: []interface{}{int64(4)}},
+		},
+		{
+			name:  "unknown field",
+			order: repository.PokemonSort{{Name: "color"}},
+			after: repository.PokemonCursor{ID: 25, Values: []interface{}{"yellow"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pokemons []*entity.Pokemon
+			if err := dryRunDB(t).Scopes(afterPokemonCursor(tt.order, tt.after)).Find(&pokemons).Error; err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestTombstoneMissingRejectsEmptyListing(t *testing.T) {
+	repo := NewPokemonRepository(dryRunDB(t))
+	for name, listedIDs := range map[string][]uint{"nil": nil, "empty": {}} {
+		t.Run(name, func(t *testing.T) {
+			tombstoned, err := repo.TombstoneMissing(context.Background(), listedIDs)
+			if !errors.Is(err, repository.ErrNoListedPokemon) {
+				t.Errorf("error = %v, want %v", err, repository.ErrNoListedPokemon)
+			}
+			if tombstoned != nil {
+				t.Errorf("tombstoned = %v, want nil", tombstoned)
+			}
+		})
+	}
+}
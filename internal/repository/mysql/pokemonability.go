@@ -7,6 +7,7 @@ import (
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
 	"github.com/AhmadNizar/cata-dtc/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type pokemonAbilityRepository struct {
@@ -28,7 +29,7 @@ func (r *pokemonAbilityRepository) Create(ctx context.Context, pokemonAbility *e
 
 func (r *pokemonAbilityRepository) GetByID(ctx context.Context, id uint) (*entity.PokemonAbility, error) {
 	var pokemonAbility entity.PokemonAbility
-	if err := r.db.WithContext(ctx).First(&pokemonAbility, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Ability").First(&pokemonAbility, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -39,7 +40,7 @@ func (r *pokemonAbilityRepository) GetByID(ctx context.Context, id uint) (*entit
 
 func (r *pokemonAbilityRepository) GetByPokemonID(ctx context.Context, pokemonID uint) ([]*entity.PokemonAbility, error) {
 	var pokemonAbilities []*entity.PokemonAbility
-	if err := r.db.WithContext(ctx).Where("pokemon_id = ?", pokemonID).Find(&pokemonAbilities).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Ability").Where("pokemon_id = ?", pokemonID).Scopes(orderBySlot).Find(&pokemonAbilities).Error; err != nil {
 		return nil, fmt.Errorf("getting pokemon abilities by pokemon id: %w", err)
 	}
 	return pokemonAbilities, nil
@@ -47,7 +48,7 @@ func (r *pokemonAbilityRepository) GetByPokemonID(ctx context.Context, pokemonID
 
 func (r *pokemonAbilityRepository) List(ctx context.Context, limit, offset int) ([]*entity.PokemonAbility, error) {
 	var pokemonAbilities []*entity.PokemonAbility
-	query := r.db.WithContext(ctx).Order("id ASC")
+	query := r.db.WithContext(ctx).Preload("Ability").Order("id ASC")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -90,4 +91,114 @@ func (r *pokemonAbilityRepository) Count(ctx context.Context) (int64, error) {
 		return 0, fmt.Errorf("counting pokemon abilities: %w", err)
 	}
 	return count, nil
-}
\ No newline at end of file
+}
+
+func (r *pokemonAbilityRepository) ListByAbilityID(ctx context.Context, abilityID uint, limit, offset int) ([]*entity.PokemonAbility, int64, error) {
+	// Links of tombstoned Pokemon are kept for when they are restored
+	listed := r.db.WithContext(ctx).Model(&entity.Pokemon{}).Select("id")
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&entity.PokemonAbility{}).Where("ability_id = ? AND pokemon_id IN (?)", abilityID, listed).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("counting pokemon abilities by ability id: %w", err)
+	}
+
+	var pokemonAbilities []*entity.PokemonAbility
+	query := r.db.WithContext(ctx).Preload("Pokemon").Where("ability_id = ? AND pokemon_id IN (?)", abilityID, listed).Order("pokemon_id ASC")
+	query = preloadTranslations(ctx, query, "Pokemon.Species")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&pokemonAbilities).Error; err != nil {
+		return nil, 0, fmt.Errorf("listing pokemon abilities by ability id: %w", err)
+	}
+
+	return pokemonAbilities, total, nil
+}
+
+func (r *pokemonAbilityRepository) GetAbilityByName(ctx context.Context, name string) (*entity.Ability, error) {
+	var ability entity.Ability
+	if err := preloadTranslations(ctx, r.db.WithContext(ctx), "").Where("name = ?", name).First(&ability).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting ability by name: %w", err)
+	}
+	return &ability, nil
+}
+
+func (r *pokemonAbilityRepository) ListAbilities(ctx context.Context, limit, offset int) ([]*entity.Ability, error) {
+	var abilities []*entity.Ability
+	query := preloadTranslations(ctx, r.db.WithContext(ctx), "").Order("name ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&abilities).Error; err != nil {
+		return nil, fmt.Errorf("listing abilities: %w", err)
+	}
+
+	return abilities, nil
+}
+
+func (r *pokemonAbilityRepository) ListAbilitiesAfter(ctx context.Context, afterName string, limit int) ([]*entity.Ability, error) {
+	var abilities []*entity.Ability
+	query := preloadTranslations(ctx, r.db.WithContext(ctx), "").Where("name > ?", afterName).Order("name ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&abilities).Error; err != nil {
+		return nil, fmt.Errorf("listing abilities after cursor: %w", err)
+	}
+
+	return abilities, nil
+}
+
+func (r *pokemonAbilityRepository) CountAbilities(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.Ability{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("counting abilities: %w", err)
+	}
+	return count, nil
+}
+
+func (r *pokemonAbilityRepository) SaveAbility(ctx context.Context, ability *entity.Ability) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"effect", "short_effect", "generation", "updated_at"}),
+		}).Create(ability).Error
+		if err != nil {
+			return fmt.Errorf("saving ability: %w", err)
+		}
+
+		// The insert ID is not the ability's when the upsert updated it
+		if err := tx.Model(&entity.Ability{}).Select("id").Where("name = ?", ability.Name).Scan(&ability.ID).Error; err != nil {
+			return fmt.Errorf("looking up ability id: %w", err)
+		}
+
+		for i := range ability.Translations {
+			ability.Translations[i].ID = 0
+			ability.Translations[i].AbilityID = ability.ID
+		}
+		if err := replaceTranslations(tx, "ability_id", ability.ID, ability.Translations); err != nil {
+			return fmt.Errorf("saving ability translations: %w", err)
+		}
+
+		return nil
+	})
+}
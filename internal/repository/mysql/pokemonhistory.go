@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+)
+
+type pokemonHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPokemonHistoryRepository(db *gorm.DB) repository.PokemonHistoryRepository {
+	return &pokemonHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *pokemonHistoryRepository) ListByPokemonID(ctx context.Context, pokemonID uint, limit, offset int) ([]*entity.PokemonHistory, error) {
+	var history []*entity.PokemonHistory
+	query := r.db.WithContext(ctx).Where("pokemon_id = ?", pokemonID).Order("version DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("listing pokemon history: %w", err)
+	}
+
+	return history, nil
+}
+
+func (r *pokemonHistoryRepository) CountByPokemonID(ctx context.Context, pokemonID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.PokemonHistory{}).Where("pokemon_id = ?", pokemonID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("counting pokemon history: %w", err)
+	}
+	return count, nil
+}
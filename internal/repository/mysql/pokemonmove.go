@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+)
+
+type pokemonMoveRepository struct {
+	db *gorm.DB
+}
+
+func NewPokemonMoveRepository(db *gorm.DB) repository.PokemonMoveRepository {
+	return &pokemonMoveRepository{
+		db: db,
+	}
+}
+
+func (r *pokemonMoveRepository) GetByPokemonID(ctx context.Context, pokemonID uint, filter repository.PokemonMoveFilter) ([]*entity.PokemonMove, error) {
+	var pokemonMoves []*entity.PokemonMove
+	query := applyPokemonMoveFilter(r.db.WithContext(ctx), filter).
+		Preload("Move").
+		Where("pokemon_id = ?", pokemonID).
+		Order("version_group ASC, learn_method ASC, level ASC, move_name ASC")
+
+	if err := query.Find(&pokemonMoves).Error; err != nil {
+		return nil, fmt.Errorf("getting pokemon moves by pokemon id: %w", err)
+	}
+
+	return pokemonMoves, nil
+}
+
+func (r *pokemonMoveRepository) ListPokemonByMove(ctx context.Context, moveID uint, filter repository.PokemonMoveFilter, limit, offset int) ([]*entity.Pokemon, int64, error) {
+	return r.listPokemonByMove(ctx, moveID, filter, func(query *gorm.DB) *gorm.DB {
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		if offset > 0 {
+			query = query.Offset(offset)
+		}
+		return query
+	})
+}
+
+func (r *pokemonMoveRepository) ListPokemonByMoveAfter(ctx context.Context, moveID uint, filter repository.PokemonMoveFilter, afterID uint, limit int) ([]*entity.Pokemon, int64, error) {
+	return r.listPokemonByMove(ctx, moveID, filter, func(query *gorm.DB) *gorm.DB {
+		query = query.Where("id > ?", afterID)
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		return query
+	})
+}
+
+// listPokemonByMove counts the Pokemon that can learn a move and lists
+// those selected by page, in ID order.
+func (r *pokemonMoveRepository) listPokemonByMove(ctx context.Context, moveID uint, filter repository.PokemonMoveFilter, page func(*gorm.DB) *gorm.DB) ([]*entity.Pokemon, int64, error) {
+	learners := applyPokemonMoveFilter(r.db.WithContext(ctx).Model(&entity.PokemonMove{}), filter).
+		Select("pokemon_id").
+		Where("move_id = ?", moveID)
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&entity.Pokemon{}).Where("id IN (?)", learners).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("counting pokemon by move: %w", err)
+	}
+
+	var pokemons []*entity.Pokemon
+	query := r.db.WithContext(ctx).Where("id IN (?)", learners).Order("id ASC").Scopes(page)
+
+	if err := query.Find(&pokemons).Error; err != nil {
+		return nil, 0, fmt.Errorf("listing pokemon by move: %w", err)
+	}
+
+	return pokemons, total, nil
+}
+
+func applyPokemonMoveFilter(query *gorm.DB, filter repository.PokemonMoveFilter) *gorm.DB {
+	if filter.LearnMethod != "" {
+		query = query.Where("learn_method = ?", filter.LearnMethod)
+	}
+	if filter.VersionGroup != "" {
+		query = query.Where("version_group = ?", filter.VersionGroup)
+	}
+	return query
+}
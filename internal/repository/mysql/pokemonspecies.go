@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type pokemonSpeciesRepository struct {
+	db *gorm.DB
+}
+
+func NewPokemonSpeciesRepository(db *gorm.DB) repository.PokemonSpeciesRepository {
+	return &pokemonSpeciesRepository{
+		db: db,
+	}
+}
+
+func (r *pokemonSpeciesRepository) GetByID(ctx context.Context, id uint) (*entity.PokemonSpecies, error) {
+	var species entity.PokemonSpecies
+	if err := r.db.WithContext(ctx).First(&species, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting pokemon species by id: %w", err)
+	}
+	return &species, nil
+}
+
+func (r *pokemonSpeciesRepository) CreateOrUpdate(ctx context.Context, species *entity.PokemonSpecies) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "evolution_chain_id", "evolves_from_species_id", "updated_at"}),
+		}).Create(species).Error
+		if err != nil {
+			return fmt.Errorf("saving pokemon species: %w", err)
+		}
+
+		for i := range species.Translations {
+			species.Translations[i].ID = 0
+			species.Translations[i].SpeciesID = species.ID
+		}
+		if err := replaceTranslations(tx, "species_id", species.ID, species.Translations); err != nil {
+			return fmt.Errorf("saving pokemon species translations: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+)
+
+type pokemonStatRepository struct {
+	db *gorm.DB
+}
+
+func NewPokemonStatRepository(db *gorm.DB) repository.PokemonStatRepository {
+	return &pokemonStatRepository{
+		db: db,
+	}
+}
+
+func (r *pokemonStatRepository) Create(ctx context.Context, pokemonStat *entity.PokemonStat) error {
+	if err := r.db.WithContext(ctx).Create(pokemonStat).Error; err != nil {
+		return fmt.Errorf("creating pokemon stat: %w", err)
+	}
+	return nil
+}
+
+func (r *pokemonStatRepository) GetByID(ctx context.Context, id uint) (*entity.PokemonStat, error) {
+	var pokemonStat entity.PokemonStat
+	if err := r.db.WithContext(ctx).First(&pokemonStat, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting pokemon stat by id: %w", err)
+	}
+	return &pokemonStat, nil
+}
+
+func (r *pokemonStatRepository) GetByPokemonID(ctx context.Context, pokemonID uint) ([]*entity.PokemonStat, error) {
+	var pokemonStats []*entity.PokemonStat
+	if err := r.db.WithContext(ctx).Where("pokemon_id = ?", pokemonID).Find(&pokemonStats).Error; err != nil {
+		return nil, fmt.Errorf("getting pokemon stats by pokemon id: %w", err)
+	}
+	return pokemonStats, nil
+}
+
+func (r *pokemonStatRepository) List(ctx context.Context, limit, offset int) ([]*entity.PokemonStat, error) {
+	var pokemonStats []*entity.PokemonStat
+	query := r.db.WithContext(ctx).Order("id ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&pokemonStats).Error; err != nil {
+		return nil, fmt.Errorf("listing pokemon stats: %w", err)
+	}
+
+	return pokemonStats, nil
+}
+
+func (r *pokemonStatRepository) Update(ctx context.Context, pokemonStat *entity.PokemonStat) error {
+	if err := r.db.WithContext(ctx).Save(pokemonStat).Error; err != nil {
+		return fmt.Errorf("updating pokemon stat: %w", err)
+	}
+	return nil
+}
+
+func (r *pokemonStatRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.PokemonStat{}, id).Error; err != nil {
+		return fmt.Errorf("deleting pokemon stat: %w", err)
+	}
+	return nil
+}
+
+func (r *pokemonStatRepository) DeleteByPokemonID(ctx context.Context, pokemonID uint) error {
+	if err := r.db.WithContext(ctx).Where("pokemon_id = ?", pokemonID).Delete(&entity.PokemonStat{}).Error; err != nil {
+		return fmt.Errorf("deleting pokemon stats by pokemon id: %w", err)
+	}
+	return nil
+}
+
+func (r *pokemonStatRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.PokemonStat{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("counting pokemon stats: %w", err)
+	}
+	return count, nil
+}
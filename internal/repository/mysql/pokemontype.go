@@ -28,7 +28,7 @@ func (r *pokemonTypeRepository) Create(ctx context.Context, pokemonType *entity.
 
 func (r *pokemonTypeRepository) GetByID(ctx context.Context, id uint) (*entity.PokemonType, error) {
 	var pokemonType entity.PokemonType
-	if err := r.db.WithContext(ctx).First(&pokemonType, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Type").First(&pokemonType, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -39,7 +39,7 @@ func (r *pokemonTypeRepository) GetByID(ctx context.Context, id uint) (*entity.P
 
 func (r *pokemonTypeRepository) GetByPokemonID(ctx context.Context, pokemonID uint) ([]*entity.PokemonType, error) {
 	var pokemonTypes []*entity.PokemonType
-	if err := r.db.WithContext(ctx).Where("pokemon_id = ?", pokemonID).Find(&pokemonTypes).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Type").Where("pokemon_id = ?", pokemonID).Scopes(orderBySlot).Find(&pokemonTypes).Error; err != nil {
 		return nil, fmt.Errorf("getting pokemon types by pokemon id: %w", err)
 	}
 	return pokemonTypes, nil
@@ -47,7 +47,7 @@ func (r *pokemonTypeRepository) GetByPokemonID(ctx context.Context, pokemonID ui
 
 func (r *pokemonTypeRepository) List(ctx context.Context, limit, offset int) ([]*entity.PokemonType, error) {
 	var pokemonTypes []*entity.PokemonType
-	query := r.db.WithContext(ctx).Order("id ASC")
+	query := r.db.WithContext(ctx).Preload("Type").Order("id ASC")
 
 	if limit > 0 {
 		query = query.Limit(limit)
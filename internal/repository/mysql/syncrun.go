@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+)
+
+type syncRunRepository struct {
+	db *gorm.DB
+}
+
+func NewSyncRunRepository(db *gorm.DB) repository.SyncRunRepository {
+	return &syncRunRepository{
+		db: db,
+	}
+}
+
+func (r *syncRunRepository) Create(ctx context.Context, syncRun *entity.SyncRun) error {
+	if err := r.db.WithContext(ctx).Create(syncRun).Error; err != nil {
+		return fmt.Errorf("creating sync run: %w", err)
+	}
+	return nil
+}
+
+func (r *syncRunRepository) GetByID(ctx context.Context, id uint) (*entity.SyncRun, error) {
+	var syncRun entity.SyncRun
+	if err := r.db.WithContext(ctx).First(&syncRun, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting sync run by id: %w", err)
+	}
+	return &syncRun, nil
+}
+
+// List returns the most recent sync runs first.
+func (r *syncRunRepository) List(ctx context.Context, limit, offset int) ([]*entity.SyncRun, error) {
+	var syncRuns []*entity.SyncRun
+	query := r.db.WithContext(ctx).Order("started_at DESC").Order("id DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&syncRuns).Error; err != nil {
+		return nil, fmt.Errorf("listing sync runs: %w", err)
+	}
+
+	return syncRuns, nil
+}
+
+func (r *syncRunRepository) Update(ctx context.Context, syncRun *entity.SyncRun) error {
+	if err := r.db.WithContext(ctx).Save(syncRun).Error; err != nil {
+		return fmt.Errorf("updating sync run: %w", err)
+	}
+	return nil
+}
+
+func (r *syncRunRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.SyncRun{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("counting sync runs: %w", err)
+	}
+	return count, nil
+}
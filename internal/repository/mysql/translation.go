@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+)
+
+// preloadTranslations loads the translations of each association, such as
+// "Types.Type", in the languages attached to ctx. An empty association
+// stands for the queried model itself.
+func preloadTranslations(ctx context.Context, query *gorm.DB, associations ...string) *gorm.DB {
+	languages := repository.LanguagesFromContext(ctx)
+	if len(languages) == 0 {
+		return query
+	}
+
+	for _, association := range associations {
+		path := "Translations"
+		if association != "" {
+			path = association + "." + path
+		}
+		query = query.Preload(path, "language IN ?", languages)
+	}
+	return query
+}
+
+// replaceTranslations replaces the translations of a resource, identified
+// by ownerColumn = ownerID, with rows.
+func replaceTranslations[T any](tx *gorm.DB, ownerColumn string, ownerID uint, rows []T) error {
+	var model T
+	if err := tx.Where(ownerColumn+" = ?", ownerID).Delete(&model).Error; err != nil {
+		return fmt.Errorf("deleting translations: %w", err)
+	}
+	if err := createRows(tx, rows); err != nil {
+		return fmt.Errorf("creating translations: %w", err)
+	}
+	return nil
+}
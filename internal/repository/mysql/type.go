@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type typeRepository struct {
+	db *gorm.DB
+}
+
+func NewTypeRepository(db *gorm.DB) repository.TypeRepository {
+	return &typeRepository{
+		db: db,
+	}
+}
+
+func (r *typeRepository) GetByName(ctx context.Context, name string) (*entity.Type, error) {
+	var pokemonType entity.Type
+	if err := preloadTranslations(ctx, r.db.WithContext(ctx), "").Where("name = ?", name).First(&pokemonType).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting type by name: %w", err)
+	}
+	return &pokemonType, nil
+}
+
+func (r *typeRepository) GetDamageRelations(ctx context.Context, typeID uint) ([]*entity.TypeDamageRelation, error) {
+	var relations []*entity.TypeDamageRelation
+	query := r.db.WithContext(ctx).
+		Preload("AttackingType").
+		Preload("DefendingType")
+	err := preloadTranslations(ctx, query, "AttackingType", "DefendingType").
+		Where("attacking_type_id = ? OR defending_type_id = ?", typeID, typeID).
+		Order("id").
+		Find(&relations).Error
+	if err != nil {
+		return nil, fmt.Errorf("getting type damage relations: %w", err)
+	}
+	return relations, nil
+}
+
+func (r *typeRepository) ReplaceDamageRelations(ctx context.Context, attackingType string, multipliers map[string]float64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		names := []string{attackingType}
+		for name := range multipliers {
+			if name != attackingType {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names[1:])
+
+		ids, err := resolveMasterIDs(tx, &entity.Type{}, names, func(name string) interface{} {
+			return &entity.Type{Name: name}
+		})
+		if err != nil {
+			return fmt.Errorf("resolving types: %w", err)
+		}
+		attackingTypeID := ids[attackingType]
+
+		if err := tx.Where("attacking_type_id = ?", attackingTypeID).Delete(&entity.TypeDamageRelation{}).Error; err != nil {
+			return fmt.Errorf("deleting type damage relations: %w", err)
+		}
+		if err := tx.Model(&entity.Type{}).Where("id = ?", attackingTypeID).Update("damage_relations_synced_at", time.Now()).Error; err != nil {
+			return fmt.Errorf("marking type damage relations synced: %w", err)
+		}
+
+		relations := make([]entity.TypeDamageRelation, 0, len(multipliers))
+		for _, name := range names {
+			multiplier, ok := multipliers[name]
+			if !ok {
+				continue
+			}
+			relations = append(relations, entity.TypeDamageRelation{
+				AttackingTypeID: attackingTypeID,
+				DefendingTypeID: ids[name],
+				Multiplier:      multiplier,
+			})
+		}
+		if len(relations) == 0 {
+			return nil
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&relations).Error; err != nil {
+			return fmt.Errorf("creating type damage relations: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *typeRepository) SaveTranslations(ctx context.Context, name string, translations []entity.TypeTranslation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkFence(ctx, tx); err != nil {
+			return err
+		}
+
+		ids, err := resolveMasterIDs(tx, &entity.Type{}, []string{name}, func(name string) interface{} {
+			return &entity.Type{Name: name}
+		})
+		if err != nil {
+			return fmt.Errorf("resolving type: %w", err)
+		}
+
+		for i := range translations {
+			translations[i].ID = 0
+			translations[i].TypeID = ids[name]
+		}
+		if err := replaceTranslations(tx, "type_id", ids[name], translations); err != nil {
+			return fmt.Errorf("saving type translations: %w", err)
+		}
+
+		return nil
+	})
+}
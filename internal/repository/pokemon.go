@@ -2,20 +2,132 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
 )
 
+// ErrNoListedPokemon is returned by TombstoneMissing when it is given no
+// listed IDs, which would otherwise tombstone every Pokemon.
+var ErrNoListedPokemon = errors.New("no listed pokemon to keep")
+
+// PokemonFilter narrows a list of Pokemon. The zero value lists the
+// default variety of each species only.
+type PokemonFilter struct {
+	// IncludeForms also lists regional, mega and other forms.
+	IncludeForms bool
+	// Form lists only the Pokemon with this form name, e.g. "alola".
+	Form string
+
+	// Types lists the Pokemon with any of these types, or with all of them
+	// when AllTypes is set.
+	Types    []string
+	AllTypes bool
+	// Ability lists the Pokemon with this ability. HiddenAbility, when
+	// set, also requires it to be or not be their hidden ability; without
+	// an Ability it applies to any of their abilities.
+	Ability       string
+	HiddenAbility *bool
+
+	Height  IntRange
+	Weight  IntRange
+	BaseExp IntRange
+	Order   IntRange
+}
+
+// IntRange bounds a numeric field, inclusively. A nil bound is open.
+type IntRange struct {
+	Min *int
+	Max *int
+}
+
+// PokemonSortFields are the fields a list of Pokemon can be sorted on.
+var PokemonSortFields = []string{"id", "name", "height", "weight", "base_experience", "order"}
+
+// PokemonSort orders a list of Pokemon by one or more of PokemonSortFields.
+// Pokemon that tie on every field are ordered by ID, so the order is the
+// same on every read. The zero value sorts by ID.
+type PokemonSort []SortField
+
+type SortField struct {
+	Name string
+	Desc bool
+}
+
+// String formats the sort as given in a query, e.g. "-base_experience,name".
+func (s PokemonSort) String() string {
+	fields := make([]string, len(s))
+	for i, field := range s {
+		fields[i] = field.Name
+		if field.Desc {
+			fields[i] = "-" + field.Name
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// ValuesOf returns the values of the sort fields of a Pokemon, in order.
+func (s PokemonSort) ValuesOf(pokemon *entity.Pokemon) []interface{} {
+	values := make([]interface{}, len(s))
+	for i, field := range s {
+		switch field.Name {
+		case "id":
+			values[i] = pokemon.ID
+		case "name":
+			values[i] = pokemon.Name
+		case "height":
+			values[i] = pokemon.Height
+		case "weight":
+			values[i] = pokemon.Weight
+		case "base_experience":
+			values[i] = pokemon.BaseExp
+		case "order":
+			values[i] = pokemon.OrderNum
+		}
+	}
+	return values
+}
+
+// PokemonCursor is a position in a sorted list of Pokemon for keyset
+// pagination: the ID and the values of the sort fields of the last Pokemon
+// of the previous page.
+type PokemonCursor struct {
+	ID     uint
+	Values []interface{}
+}
+
 type PokemonRepository interface {
 	Create(ctx context.Context, pokemon *entity.Pokemon) error
 	GetByID(ctx context.Context, id uint) (*entity.Pokemon, error)
 	GetByIDWithRelations(ctx context.Context, id uint) (*entity.Pokemon, error)
 	GetByName(ctx context.Context, name string) (*entity.Pokemon, error)
 	GetByNameWithRelations(ctx context.Context, name string) (*entity.Pokemon, error)
-	List(ctx context.Context, limit, offset int) ([]*entity.Pokemon, error)
-	ListWithRelations(ctx context.Context, limit, offset int) ([]*entity.Pokemon, error)
+	List(ctx context.Context, order PokemonSort, limit, offset int) ([]*entity.Pokemon, error)
+	ListWithRelations(ctx context.Context, filter PokemonFilter, order PokemonSort, limit, offset int) ([]*entity.Pokemon, error)
+	// ListWithRelationsAfter returns the Pokemon that come after the cursor
+	// in sort order, so that a page does not shift when Pokemon are added
+	// to or removed from earlier pages. The cursor must hold a value for
+	// each field of sort.
+	ListWithRelationsAfter(ctx context.Context, filter PokemonFilter, order PokemonSort, after PokemonCursor, limit int) ([]*entity.Pokemon, error)
 	Update(ctx context.Context, pokemon *entity.Pokemon) error
 	Delete(ctx context.Context, id uint) error
-	Count(ctx context.Context) (int64, error)
-	CreateOrUpdate(ctx context.Context, pokemon *entity.Pokemon) error
-}
\ No newline at end of file
+	Count(ctx context.Context, filter PokemonFilter) (int64, error)
+	// ListVarietyIDs returns the IDs of the stored forms of the species of
+	// the given Pokemon, leaving out their default varieties.
+	ListVarietyIDs(ctx context.Context, pokemonIDs []uint) ([]uint, error)
+	// CreateOrUpdate matches the stored Pokemon by ID, then by name, and
+	// also restores it when it is tombstoned.
+	CreateOrUpdate(ctx context.Context, pokemon *entity.Pokemon) (entity.UpsertResult, error)
+	// TombstoneMissing soft deletes every Pokemon whose ID is not in
+	// listedIDs and returns the Pokemon it deleted. It refuses an empty
+	// listedIDs with ErrNoListedPokemon.
+	TombstoneMissing(ctx context.Context, listedIDs []uint) ([]*entity.Pokemon, error)
+	// ListDeleted returns a page of tombstoned Pokemon, most recently
+	// deleted first.
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entity.Pokemon, error)
+	CountDeleted(ctx context.Context) (int64, error)
+	// Restore clears the tombstone of a Pokemon. It returns nil when no
+	// tombstoned Pokemon has that ID.
+	Restore(ctx context.Context, id uint) (*entity.Pokemon, error)
+}
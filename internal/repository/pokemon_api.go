@@ -2,10 +2,34 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
 )
 
+// ErrNotModified is returned by conditional fetches when upstream reports
+// that the resource has not changed since it was last fetched.
+var ErrNotModified = errors.New("resource not modified")
+
+// ErrNotFound is returned when upstream has no such resource.
+var ErrNotFound = errors.New("resource not found")
+
 type PokemonAPIRepository interface {
 	GetPokemon(ctx context.Context, id int) (*entity.PokemonAPIResponse, error)
-}
\ No newline at end of file
+	// ForgetPokemon drops the validators remembered for a Pokemon so that
+	// the next GetPokemon fetches it in full.
+	ForgetPokemon(id int)
+	// LookupPokemon always fetches a Pokemon in full by ID or name.
+	LookupPokemon(ctx context.Context, idOrName string) (*entity.PokemonAPIResponse, error)
+	ListPokemon(ctx context.Context, limit, offset int) (*entity.PokemonListAPIResponse, error)
+	GetPokemonSpecies(ctx context.Context, id int) (*entity.PokemonSpeciesAPIResponse, error)
+	GetEvolutionChain(ctx context.Context, id int) (*entity.EvolutionChainAPIResponse, error)
+	GetMove(ctx context.Context, id int) (*entity.MoveAPIResponse, error)
+	GetAbility(ctx context.Context, id int) (*entity.AbilityAPIResponse, error)
+	// ListTypes lists every upstream type in a single page.
+	ListTypes(ctx context.Context) ([]entity.NamedAPIResource, error)
+	GetType(ctx context.Context, name string) (*entity.TypeAPIResponse, error)
+	// DownloadSprite fetches a sprite image from the URL given in a
+	// Pokemon's sprites.
+	DownloadSprite(ctx context.Context, url string) ([]byte, error)
+}
@@ -15,4 +15,17 @@ type PokemonAbilityRepository interface {
 	Delete(ctx context.Context, id uint) error
 	DeleteByPokemonID(ctx context.Context, pokemonID uint) error
 	Count(ctx context.Context) (int64, error)
+	// ListByAbilityID returns a page of the links to an ability, with their
+	// Pokemon loaded, along with how many there are in total.
+	ListByAbilityID(ctx context.Context, abilityID uint, limit, offset int) ([]*entity.PokemonAbility, int64, error)
+
+	GetAbilityByName(ctx context.Context, name string) (*entity.Ability, error)
+	ListAbilities(ctx context.Context, limit, offset int) ([]*entity.Ability, error)
+	// ListAbilitiesAfter returns the abilities whose names sort after
+	// afterName.
+	ListAbilitiesAfter(ctx context.Context, afterName string, limit int) ([]*entity.Ability, error)
+	CountAbilities(ctx context.Context) (int64, error)
+	// SaveAbility creates an ability or updates its details and
+	// translations, matched by name.
+	SaveAbility(ctx context.Context, ability *entity.Ability) error
 }
\ No newline at end of file
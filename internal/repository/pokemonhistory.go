@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// PokemonHistoryRepository reads the change log of Pokemon. Entries are
+// written by PokemonRepository.CreateOrUpdate, in the same transaction as
+// the update they record.
+type PokemonHistoryRepository interface {
+	// ListByPokemonID returns a page of the change log of a Pokemon,
+	// newest version first.
+	ListByPokemonID(ctx context.Context, pokemonID uint, limit, offset int) ([]*entity.PokemonHistory, error)
+	CountByPokemonID(ctx context.Context, pokemonID uint) (int64, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// PokemonMoveFilter narrows learnset queries. Empty fields match anything.
+type PokemonMoveFilter struct {
+	LearnMethod  string
+	VersionGroup string
+}
+
+type PokemonMoveRepository interface {
+	// GetByPokemonID returns a Pokemon's learnset with each move's details.
+	GetByPokemonID(ctx context.Context, pokemonID uint, filter PokemonMoveFilter) ([]*entity.PokemonMove, error)
+	// ListPokemonByMove returns the Pokemon that can learn a move, ordered
+	// by ID, and how many there are in total.
+	ListPokemonByMove(ctx context.Context, moveID uint, filter PokemonMoveFilter, limit, offset int) ([]*entity.Pokemon, int64, error)
+	// ListPokemonByMoveAfter is ListPokemonByMove for the page that starts
+	// after the Pokemon with ID afterID.
+	ListPokemonByMoveAfter(ctx context.Context, moveID uint, filter PokemonMoveFilter, afterID uint, limit int) ([]*entity.Pokemon, int64, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+type PokemonSpeciesRepository interface {
+	GetByID(ctx context.Context, id uint) (*entity.PokemonSpecies, error)
+	CreateOrUpdate(ctx context.Context, species *entity.PokemonSpecies) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+type PokemonStatRepository interface {
+	Create(ctx context.Context, pokemonStat *entity.PokemonStat) error
+	GetByID(ctx context.Context, id uint) (*entity.PokemonStat, error)
+	GetByPokemonID(ctx context.Context, pokemonID uint) ([]*entity.PokemonStat, error)
+	List(ctx context.Context, limit, offset int) ([]*entity.PokemonStat, error)
+	Update(ctx context.Context, pokemonStat *entity.PokemonStat) error
+	Delete(ctx context.Context, id uint) error
+	DeleteByPokemonID(ctx context.Context, pokemonID uint) error
+	Count(ctx context.Context) (int64, error)
+}
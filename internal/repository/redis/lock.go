@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// renewScript extends the lease only if it is still owned by the caller.
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript deletes the lock only if it is still owned by the caller.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type LockRepository struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewLockRepository(client *redis.Client, prefix string) *LockRepository {
+	return &LockRepository{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Acquire takes the named lock for ttl and keeps renewing it every third of
+// ttl until it is released. It returns repository.ErrLockHeld when another
+// owner holds the lock.
+func (r *LockRepository) Acquire(ctx context.Context, name string, ttl time.Duration) (repository.Lock, error) {
+	owner, err := newLockOwner()
+	if err != nil {
+		return nil, fmt.Errorf("generating lock owner: %w", err)
+	}
+
+	key := r.getKey("lock:" + name)
+	ok, err := r.client.SetNX(ctx, key, owner, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("acquiring lock: %w", err)
+	}
+	if !ok {
+		return nil, repository.ErrLockHeld
+	}
+
+	lock := &redisLock{
+		client: r.client,
+		key:    key,
+		owner:  owner,
+		ttl:    ttl,
+		lost:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go lock.renew()
+
+	return lock, nil
+}
+
+func (r *LockRepository) getKey(key string) string {
+	if r.prefix == "" {
+		return key
+	}
+	return fmt.Sprintf("%s:%s", r.prefix, key)
+}
+
+type redisLock struct {
+	client *redis.Client
+	key    string
+	owner  string
+	ttl    time.Duration
+
+	lost      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *redisLock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+func (l *redisLock) Release(ctx context.Context) error {
+	l.closeOnce.Do(func() { close(l.done) })
+
+	if err := releaseScript.Run(ctx, l.client, []string{l.key}, l.owner).Err(); err != nil {
+		return fmt.Errorf("releasing lock: %w", err)
+	}
+	return nil
+}
+
+// renew extends the lease until the lock is released. The lease counts as
+// lost when Redis says someone else owns it, or when it could not be
+// renewed before it would have expired.
+func (l *redisLock) renew() {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	renewedAt := time.Now()
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+		extended, err := renewScript.Run(ctx, l.client, []string{l.key}, l.owner, l.ttl.Milliseconds()).Int()
+		cancel()
+
+		switch {
+		case err == nil && extended == 1:
+			renewedAt = time.Now()
+			continue
+		case err == nil:
+			log.Printf("⚠️ Lock %s was taken over by another owner", l.key)
+		case time.Since(renewedAt) < l.ttl:
+			log.Printf("Warning: failed to renew lock %s, will retry: %v", l.key, err)
+			continue
+		default:
+			log.Printf("⚠️ Lock %s expired before it could be renewed: %v", l.key, err)
+		}
+
+		close(l.lost)
+		return
+	}
+}
+
+func newLockOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
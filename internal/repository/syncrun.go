@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+type SyncRunRepository interface {
+	Create(ctx context.Context, syncRun *entity.SyncRun) error
+	GetByID(ctx context.Context, id uint) (*entity.SyncRun, error)
+	List(ctx context.Context, limit, offset int) ([]*entity.SyncRun, error)
+	Update(ctx context.Context, syncRun *entity.SyncRun) error
+	Count(ctx context.Context) (int64, error)
+}
+
+type syncRunContextKey struct{}
+
+// WithSyncRunID attributes writes made with the returned context to a sync
+// run, e.g. in the change log of the Pokemon they update.
+func WithSyncRunID(ctx context.Context, id uint) context.Context {
+	return context.WithValue(ctx, syncRunContextKey{}, id)
+}
+
+// SyncRunIDFromContext returns the sync run attached by WithSyncRunID.
+func SyncRunIDFromContext(ctx context.Context) (uint, bool) {
+	id, ok := ctx.Value(syncRunContextKey{}).(uint)
+	return id, ok
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+)
+
+type languagesContextKey struct{}
+
+// WithLanguages asks repositories to load the translations of what they
+// read with the returned context, in the given languages only. Without it
+// no translations are loaded.
+func WithLanguages(ctx context.Context, languages ...string) context.Context {
+	return context.WithValue(ctx, languagesContextKey{}, languages)
+}
+
+// LanguagesFromContext returns the languages attached by WithLanguages.
+func LanguagesFromContext(ctx context.Context) []string {
+	languages, _ := ctx.Value(languagesContextKey{}).([]string)
+	return languages
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+type TypeRepository interface {
+	GetByName(ctx context.Context, name string) (*entity.Type, error)
+	// GetDamageRelations returns the relations the type takes part in,
+	// attacking or defending, with both types loaded.
+	GetDamageRelations(ctx context.Context, typeID uint) ([]*entity.TypeDamageRelation, error)
+	// ReplaceDamageRelations replaces the relations the type attacks with,
+	// keyed by defending type name, and marks them synced even when there
+	// are none. Types not stored yet are created.
+	ReplaceDamageRelations(ctx context.Context, attackingType string, multipliers map[string]float64) error
+	// SaveTranslations replaces the localized names of the type, creating
+	// it when missing.
+	SaveTranslations(ctx context.Context, name string, translations []entity.TypeTranslation) error
+}
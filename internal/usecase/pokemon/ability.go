@@ -0,0 +1,133 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// ErrAbilityNotFound is returned when an ability does not exist.
+var ErrAbilityNotFound = errors.New("ability not found")
+
+// effectLanguage is the language effect texts are stored in. Upstream has
+// few effect texts in other languages; translated descriptions come from
+// the flavor texts instead.
+const effectLanguage = entity.DefaultLanguage
+
+// ListAbilities returns a page of abilities, ordered by name, along with
+// how many there are in total and the name the next page starts after,
+// which is nil on the last page.
+func (u *usecase) ListAbilities(language string, limit, offset int) ([]*entity.Ability, int64, *string, error) {
+	ctx := withLanguage(context.Background(), language)
+
+	abilities, err := u.pokemonAbilityRepo.ListAbilities(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("fetching abilities: %w", err)
+	}
+
+	total, err := u.pokemonAbilityRepo.CountAbilities(ctx)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("counting abilities: %w", err)
+	}
+
+	var next *string
+	if len(abilities) > 0 && int64(offset+len(abilities)) < total {
+		next = &abilities[len(abilities)-1].Name
+	}
+	return abilities, total, next, nil
+}
+
+// ListAbilitiesAfter is ListAbilities for the page that starts after the
+// ability named after rather than at an offset.
+func (u *usecase) ListAbilitiesAfter(language, after string, limit int) ([]*entity.Ability, int64, *string, error) {
+	ctx := withLanguage(context.Background(), language)
+
+	// One ability past the page tells whether there is a next page.
+	abilities, err := u.pokemonAbilityRepo.ListAbilitiesAfter(ctx, after, limit+1)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("fetching abilities: %w", err)
+	}
+
+	total, err := u.pokemonAbilityRepo.CountAbilities(ctx)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("counting abilities: %w", err)
+	}
+
+	var next *string
+	if len(abilities) > limit {
+		abilities = abilities[:limit]
+		next = &abilities[len(abilities)-1].Name
+	}
+	return abilities, total, next, nil
+}
+
+// GetAbility returns an ability and a page of the Pokemon that have it,
+// along with how many there are in total.
+func (u *usecase) GetAbility(name, language string, limit, offset int) (*entity.Ability, []*entity.PokemonAbility, int64, error) {
+	ctx := withLanguage(context.Background(), language)
+
+	ability, err := u.pokemonAbilityRepo.GetAbilityByName(ctx, strings.ToLower(strings.TrimSpace(name)))
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("getting ability: %w", err)
+	}
+	if ability == nil {
+		return nil, nil, 0, ErrAbilityNotFound
+	}
+
+	pokemonAbilities, total, err := u.pokemonAbilityRepo.ListByAbilityID(ctx, ability.ID, limit, offset)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("listing pokemon by ability: %w", err)
+	}
+
+	return ability, pokemonAbilities, total, nil
+}
+
+// syncAbilities saves the details of every ability a fetched Pokemon can
+// have.
+func (u *usecase) syncAbilities(ctx context.Context, state *resourceSync, pokemonData *entity.PokemonAPIResponse) error {
+	for _, pokemonAbility := range pokemonData.Abilities {
+		abilityID := entity.NamedAPIResource(pokemonAbility.Ability).ID()
+		if abilityID == 0 {
+			continue
+		}
+
+		err := state.abilities.do(abilityID, func() error {
+			abilityData, err := u.pokemonAPIRepo.GetAbility(ctx, abilityID)
+			if err != nil {
+				return fmt.Errorf("fetching ability %d: %w", abilityID, err)
+			}
+
+			if err := u.pokemonAbilityRepo.SaveAbility(ctx, convertAPIResponseToAbility(abilityData)); err != nil {
+				return fmt.Errorf("saving ability %d: %w", abilityID, err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convertAPIResponseToAbility(apiResponse *entity.AbilityAPIResponse) *entity.Ability {
+	ability := &entity.Ability{
+		Name:       apiResponse.Name,
+		Generation: apiResponse.Generation.Name,
+	}
+	for _, entry := range apiResponse.EffectEntries {
+		if entry.Language.Name == effectLanguage {
+			ability.Effect = entry.Effect
+			ability.ShortEffect = entry.ShortEffect
+			break
+		}
+	}
+	for _, translation := range convertTranslations(apiResponse.Names, apiResponse.FlavorTextEntries) {
+		ability.Translations = append(ability.Translations, entity.AbilityTranslation{Translation: translation})
+	}
+	return ability
+}
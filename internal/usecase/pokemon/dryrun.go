@@ -0,0 +1,120 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+// DryRunSync fetches the Pokemon a sync would touch and diffs them against
+// the stored rows without writing anything. When pokemonIDs is empty the
+// configured range and allow-list are used.
+func (u *usecase) DryRunSync(pokemonIDs []int) (*entity.SyncDryRun, error) {
+	ctx := context.Background()
+
+	log.Println("Starting Pokemon data sync DRY RUN...")
+
+	if len(pokemonIDs) == 0 {
+		var err error
+		pokemonIDs, _, err = u.listPokemonIDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing upstream pokemon: %w", err)
+		}
+	}
+
+	report := &entity.SyncDryRun{
+		Total:    len(pokemonIDs),
+		Changes:  []entity.PokemonChange{},
+		Failures: []entity.SyncFailure{},
+	}
+
+	var mu sync.Mutex
+	u.forEachPokemonID(ctx, pokemonIDs, func(id int) {
+		change, err := u.previewPokemon(ctx, id)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case err != nil:
+			report.ErrorCount++
+			report.Failures = append(report.Failures, entity.SyncFailure{PokemonID: id, Reason: err.Error()})
+		case change.Action == entity.UpsertResultCreated:
+			report.CreateCount++
+			report.Changes = append(report.Changes, *change)
+		case change.Action == entity.UpsertResultUpdated:
+			report.UpdateCount++
+			report.Changes = append(report.Changes, *change)
+		default:
+			report.UnchangedCount++
+		}
+	})
+
+	sort.Slice(report.Changes, func(i, j int) bool {
+		return report.Changes[i].PokemonID < report.Changes[j].PokemonID
+	})
+	sort.Slice(report.Failures, func(i, j int) bool {
+		return report.Failures[i].PokemonID < report.Failures[j].PokemonID
+	})
+
+	log.Printf("✅ Pokemon data sync DRY RUN finished: %d to create, %d to update, %d unchanged, %d errors",
+		report.CreateCount, report.UpdateCount, report.UnchangedCount, report.ErrorCount)
+
+	return report, nil
+}
+
+// previewPokemon works out what syncing a single Pokemon would change. It
+// always fetches in full: a conditional fetch would remember validators and
+// make the next real sync skip a Pokemon that was never written.
+func (u *usecase) previewPokemon(ctx context.Context, id int) (*entity.PokemonChange, error) {
+	pokemonData, err := u.pokemonAPIRepo.LookupPokemon(ctx, strconv.Itoa(id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching pokemon: %w", err)
+	}
+
+	pokemon := convertAPIResponseToPokemon(pokemonData)
+
+	// Match by ID first, as saving does, so a rename shows as an update
+	existing, err := u.pokemonRepo.GetByIDWithRelations(ctx, pokemon.ID)
+	if err == nil && existing == nil {
+		existing, err = u.pokemonRepo.GetByNameWithRelations(ctx, pokemon.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing pokemon: %w", err)
+	}
+
+	change := &entity.PokemonChange{
+		PokemonID: id,
+		Name:      pokemon.Name,
+	}
+
+	if existing == nil {
+		change.Action = entity.UpsertResultCreated
+		change.Diff = entity.DiffPokemon(&entity.Pokemon{}, pokemon)
+		return change, nil
+	}
+
+	// Learnsets are not loaded with the other relations.
+	pokemonMoves, err := u.pokemonMoveRepo.GetByPokemonID(ctx, existing.ID, repository.PokemonMoveFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing pokemon moves: %w", err)
+	}
+	for _, pokemonMove := range pokemonMoves {
+		existing.Moves = append(existing.Moves, *pokemonMove)
+	}
+
+	change.Diff = entity.DiffPokemon(existing, pokemon)
+	if change.Diff.IsEmpty() {
+		change.Action = entity.UpsertResultUnchanged
+	} else {
+		change.Action = entity.UpsertResultUpdated
+	}
+
+	return change, nil
+}
@@ -0,0 +1,183 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// ErrEvolutionsNotFound is returned when a Pokemon's species or evolution
+// chain has not been synced.
+var ErrEvolutionsNotFound = errors.New("evolutions not found")
+
+// GetPokemonEvolutions returns the evolution chain that a Pokemon's species
+// belongs to, with species names in language.
+func (u *usecase) GetPokemonEvolutions(id uint, language string) (*entity.EvolutionChain, error) {
+	ctx := withLanguage(context.Background(), language)
+
+	pokemon, err := u.pokemonRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, ErrPokemonNotFound
+	}
+	if pokemon.SpeciesID == nil {
+		return nil, ErrEvolutionsNotFound
+	}
+
+	species, err := u.speciesRepo.GetByID(ctx, *pokemon.SpeciesID)
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon species: %w", err)
+	}
+	if species == nil || species.EvolutionChainID == nil {
+		return nil, ErrEvolutionsNotFound
+	}
+
+	chain, err := u.evolutionChainRepo.GetByIDWithLinks(ctx, *species.EvolutionChainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting evolution chain: %w", err)
+	}
+	if chain == nil {
+		return nil, ErrEvolutionsNotFound
+	}
+
+	return chain, nil
+}
+
+// syncSpecies saves the species of a fetched Pokemon, and its evolution
+// chain, before the Pokemon itself is saved with a reference to it.
+func (u *usecase) syncSpecies(ctx context.Context, state *resourceSync, pokemonData *entity.PokemonAPIResponse) error {
+	speciesID := pokemonData.Species.ID()
+	if speciesID == 0 {
+		return nil
+	}
+
+	return state.species.do(speciesID, func() error {
+		speciesData, err := u.pokemonAPIRepo.GetPokemonSpecies(ctx, speciesID)
+		if err != nil {
+			return fmt.Errorf("fetching species %d: %w", speciesID, err)
+		}
+
+		species := &entity.PokemonSpecies{
+			ID:   uint(speciesData.ID),
+			Name: speciesData.Name,
+		}
+		for _, translation := range convertTranslations(speciesData.Names, speciesData.FlavorTextEntries) {
+			species.Translations = append(species.Translations, entity.PokemonSpeciesTranslation{Translation: translation})
+		}
+		for _, variety := range speciesData.Varieties {
+			if id := variety.Pokemon.ID(); id != 0 && !variety.IsDefault {
+				state.addVariety(id)
+			}
+		}
+		if speciesData.EvolvesFromSpecies != nil {
+			species.EvolvesFromSpeciesID = optionalResourceID(*speciesData.EvolvesFromSpecies)
+		}
+
+		if chainID := speciesData.EvolutionChain.ID(); chainID != 0 {
+			if err := state.chains.do(chainID, func() error {
+				return u.syncEvolutionChain(ctx, chainID)
+			}); err != nil {
+				return err
+			}
+			species.EvolutionChainID = optionalResourceID(speciesData.EvolutionChain)
+		}
+
+		if err := u.speciesRepo.CreateOrUpdate(ctx, species); err != nil {
+			return fmt.Errorf("saving species %d: %w", speciesID, err)
+		}
+
+		return nil
+	})
+}
+
+func (u *usecase) syncEvolutionChain(ctx context.Context, chainID int) error {
+	chainData, err := u.pokemonAPIRepo.GetEvolutionChain(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("fetching evolution chain %d: %w", chainID, err)
+	}
+
+	chain := convertAPIResponseToEvolutionChain(chainData)
+	if err := u.evolutionChainRepo.CreateOrUpdate(ctx, chain); err != nil {
+		return fmt.Errorf("saving evolution chain %d: %w", chainID, err)
+	}
+
+	log.Printf("✅ Successfully synced evolution chain %d with %d species", chain.ID, len(chain.Links))
+	return nil
+}
+
+// convertAPIResponseToEvolutionChain flattens the upstream tree depth
+// first, so every link comes after the one it evolves from.
+func convertAPIResponseToEvolutionChain(apiResponse *entity.EvolutionChainAPIResponse) *entity.EvolutionChain {
+	chain := &entity.EvolutionChain{ID: uint(apiResponse.ID)}
+
+	var walk func(link entity.ChainLinkAPI, parent *uint)
+	walk = func(link entity.ChainLinkAPI, parent *uint) {
+		details := make([]entity.EvolutionDetail, len(link.EvolutionDetails))
+		for i, detail := range link.EvolutionDetails {
+			details[i] = convertEvolutionDetail(detail)
+		}
+
+		speciesID := uint(link.Species.ID())
+		chain.Links = append(chain.Links, entity.EvolutionChainLink{
+			ChainID:              chain.ID,
+			SpeciesID:            speciesID,
+			SpeciesName:          link.Species.Name,
+			EvolvesFromSpeciesID: parent,
+			IsBaby:               link.IsBaby,
+			Details:              details,
+		})
+
+		for _, next := range link.EvolvesTo {
+			walk(next, &speciesID)
+		}
+	}
+	walk(apiResponse.Chain, nil)
+
+	return chain
+}
+
+func convertEvolutionDetail(detail entity.EvolutionDetailAPI) entity.EvolutionDetail {
+	name := func(resource *entity.NamedAPIResource) string {
+		if resource == nil {
+			return ""
+		}
+		return resource.Name
+	}
+
+	return entity.EvolutionDetail{
+		Trigger:               detail.Trigger.Name,
+		MinLevel:              detail.MinLevel,
+		Item:                  name(detail.Item),
+		HeldItem:              name(detail.HeldItem),
+		MinHappiness:          detail.MinHappiness,
+		MinAffection:          detail.MinAffection,
+		MinBeauty:             detail.MinBeauty,
+		TimeOfDay:             detail.TimeOfDay,
+		Gender:                detail.Gender,
+		KnownMove:             name(detail.KnownMove),
+		KnownMoveType:         name(detail.KnownMoveType),
+		Location:              name(detail.Location),
+		PartySpecies:          name(detail.PartySpecies),
+		PartyType:             name(detail.PartyType),
+		TradeSpecies:          name(detail.TradeSpecies),
+		RelativePhysicalStats: detail.RelativePhysicalStats,
+		NeedsOverworldRain:    detail.NeedsOverworldRain,
+		TurnUpsideDown:        detail.TurnUpsideDown,
+	}
+}
+
+// optionalResourceID returns the ID of an upstream reference, or nil when
+// its URL carries none.
+func optionalResourceID(resource entity.NamedAPIResource) *uint {
+	id := resource.ID()
+	if id == 0 {
+		return nil
+	}
+	value := uint(id)
+	return &value
+}
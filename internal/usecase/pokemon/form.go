@@ -0,0 +1,75 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// formName is the part of a Pokemon's name after its species name, e.g.
+// "alola" for raichu-alola or "mega-x" for charizard-mega-x. It is empty
+// for a Pokemon named after its species.
+func formName(pokemonName, speciesName string) string {
+	if speciesName == "" || !strings.HasPrefix(pokemonName, speciesName+"-") {
+		return ""
+	}
+	return strings.TrimPrefix(pokemonName, speciesName+"-")
+}
+
+// listVarietyIDs returns the forms of the species of the synced Pokemon
+// that are not among them, so that a sync of a range of IDs also covers
+// the regional, mega and gigantamax forms of its species. Forms are found
+// from the species fetched in this sync and from the ones stored before,
+// as an unchanged Pokemon does not fetch its species again.
+func (u *usecase) listVarietyIDs(ctx context.Context, resources *resourceSync, pokemonIDs []int) ([]int, error) {
+	synced := make(map[int]bool, len(pokemonIDs))
+	listed := make([]uint, len(pokemonIDs))
+	for i, id := range pokemonIDs {
+		synced[id] = true
+		listed[i] = uint(id)
+	}
+
+	stored, err := u.pokemonRepo.ListVarietyIDs(ctx, listed)
+	if err != nil {
+		return nil, fmt.Errorf("listing stored varieties: %w", err)
+	}
+
+	var ids []int
+	seen := make(map[int]bool)
+	add := func(id int) {
+		if !synced[id] && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range resources.varietyIDs() {
+		add(id)
+	}
+	for _, id := range stored {
+		add(int(id))
+	}
+
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// syncVarieties syncs the forms of the species covered by a sync that the
+// sync itself did not list, counting them towards job.
+func (u *usecase) syncVarieties(ctx context.Context, abort context.CancelCauseFunc, job *syncJob, resources *resourceSync, pokemonIDs []int) {
+	varietyIDs, err := u.listVarietyIDs(ctx, resources, pokemonIDs)
+	if err != nil {
+		log.Printf("⚠️ Failed to list Pokemon forms: %v", err)
+		return
+	}
+	if len(varietyIDs) == 0 {
+		return
+	}
+
+	log.Printf("Found %d Pokemon forms to sync", len(varietyIDs))
+	job.update(func(j *entity.SyncJob) { j.Total += len(varietyIDs) })
+	u.syncPokemonIDs(ctx, abort, job, resources, varietyIDs)
+}
@@ -0,0 +1,34 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// GetPokemonHistory returns a page of the change log of a Pokemon, newest
+// version first, along with how many entries there are in total.
+func (u *usecase) GetPokemonHistory(id uint, limit, offset int) ([]*entity.PokemonHistory, int64, error) {
+	ctx := context.Background()
+
+	pokemon, err := u.pokemonRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, 0, ErrPokemonNotFound
+	}
+
+	history, err := u.pokemonHistoryRepo.ListByPokemonID(ctx, id, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetching pokemon history: %w", err)
+	}
+
+	total, err := u.pokemonHistoryRepo.CountByPokemonID(ctx, id)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting pokemon history: %w", err)
+	}
+
+	return history, total, nil
+}
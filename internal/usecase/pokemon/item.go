@@ -0,0 +1,92 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"golang.org/x/text/unicode/norm"
+)
+
+// GetPokemonByID returns a Pokemon with the names of its species, types and
+// abilities in language, or ErrPokemonNotFound. Each Pokemon is cached on
+// its own.
+func (u *usecase) GetPokemonByID(language string, id uint) (*entity.Pokemon, error) {
+	ctx := withLanguage(context.Background(), language)
+	return u.getPokemonItem(ctx, pokemonIDCacheKey(id, language), func() (*entity.Pokemon, error) {
+		return u.pokemonRepo.GetByIDWithRelations(ctx, id)
+	})
+}
+
+// GetPokemonByName is GetPokemonByID for a name, matched as the slug
+// upstream names Pokemon by, so "Mr. Mime" finds mr-mime.
+func (u *usecase) GetPokemonByName(language, name string) (*entity.Pokemon, error) {
+	name = normalizePokemonName(name)
+	if name == "" {
+		return nil, ErrPokemonNotFound
+	}
+
+	ctx := withLanguage(context.Background(), language)
+	return u.getPokemonItem(ctx, pokemonNameCacheKey(name, language), func() (*entity.Pokemon, error) {
+		return u.pokemonRepo.GetByNameWithRelations(ctx, name)
+	})
+}
+
+// getPokemonItem returns the Pokemon cached under cacheKey, or fetches and
+// caches it. Missing Pokemon are not cached, so a sync that adds one is seen
+// at once.
+func (u *usecase) getPokemonItem(ctx context.Context, cacheKey string, fetch func() (*entity.Pokemon, error)) (*entity.Pokemon, error) {
+	var pokemon *entity.Pokemon
+	if err := u.cache.Get(ctx, cacheKey, &pokemon); err == nil && pokemon != nil {
+		log.Println("Returning cached Pokemon")
+		return pokemon, nil
+	} else if err != nil && err.Error() != "cache miss" {
+		log.Printf("Warning: cache error: %v", err)
+	}
+
+	log.Println("Fetching Pokemon from database")
+
+	pokemon, err := fetch()
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, ErrPokemonNotFound
+	}
+
+	if err := u.cache.Set(ctx, cacheKey, pokemon, u.cacheTTL); err != nil {
+		log.Printf("Warning: failed to cache result: %v", err)
+	}
+	return pokemon, nil
+}
+
+// normalizePokemonName turns a display name into the slug upstream uses:
+// lower case, with accents and periods and apostrophes dropped, the gender
+// signs spelled -f and -m, and any other run of non-alphanumeric
+// characters replaced by a single hyphen, e.g. "Mr. Mime" -> "mr-mime",
+// "Flabébé" -> "flabebe" and "Nidoran♀" -> "nidoran-f".
+func normalizePokemonName(name string) string {
+	name = genderSigns.Replace(norm.NFD.String(name))
+
+	var slug strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r == '.' || r == '\'' || r == '’' || unicode.Is(unicode.Mn, r):
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if pendingHyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			pendingHyphen = false
+			slug.WriteRune(r)
+		default:
+			pendingHyphen = true
+		}
+	}
+	return slug.String()
+}
+
+var genderSigns = strings.NewReplacer("♀", "-f", "♂", "-m")
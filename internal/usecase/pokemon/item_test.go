@@ -0,0 +1,33 @@
+package pokemon
+
+import "testing"
+
+func TestNormalizePokemonName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"pikachu", "pikachu"},
+		{"  Pikachu  ", "pikachu"},
+		{"Mr. Mime", "mr-mime"},
+		{"Mime Jr.", "mime-jr"},
+		{"Farfetch'd", "farfetchd"},
+		{"Farfetch’d", "farfetchd"},
+		{"Type: Null", "type-null"},
+		{"Porygon-Z", "porygon-z"},
+		{"Tapu  Koko", "tapu-koko"},
+		{"Flabébé", "flabebe"},
+		{"FLABÉBÉ", "flabebe"},
+		{"Nidoran♀", "nidoran-f"},
+		{"Nidoran ♂", "nidoran-m"},
+		{"Nidoran-♀", "nidoran-f"},
+		{"!!!", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePokemonName(tt.name); got != tt.want {
+			t.Errorf("normalizePokemonName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
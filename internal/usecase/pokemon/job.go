@@ -0,0 +1,122 @@
+package pokemon
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// ErrSyncInProgress is returned when a sync is requested while another one
+// is still running.
+var ErrSyncInProgress = errors.New("a pokemon sync is already in progress")
+
+// ErrSyncLeaseLost is returned when a sync is aborted because its lease on
+// the cluster-wide sync lock was lost.
+var ErrSyncLeaseLost = errors.New("sync lease lost to another instance")
+
+// maxTrackedSyncJobs bounds how many finished jobs are kept for lookup.
+const maxTrackedSyncJobs = 50
+
+// syncJob guards the progress of one sync so that workers can update it
+// while handlers read it.
+type syncJob struct {
+	mu  sync.Mutex
+	job entity.SyncJob
+}
+
+func (j *syncJob) snapshot() *entity.SyncJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job := j.job
+	job.Failures = append([]entity.SyncFailure(nil), j.job.Failures...)
+	return &job
+}
+
+func (j *syncJob) update(fn func(job *entity.SyncJob)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.job)
+}
+
+// syncJobTracker makes sure at most one sync runs at a time in this process
+// and remembers the most recent jobs.
+type syncJobTracker struct {
+	mu      sync.Mutex
+	current *syncJob
+	jobs    map[string]*syncJob
+	order   []string
+}
+
+func newSyncJobTracker() *syncJobTracker {
+	return &syncJobTracker{
+		jobs: make(map[string]*syncJob),
+	}
+}
+
+// start registers a new running job. When a job is already running it is
+// returned instead, together with false.
+func (t *syncJobTracker) start(trigger entity.SyncTrigger) (*syncJob, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current != nil {
+		return t.current, false
+	}
+
+	job := &syncJob{job: entity.SyncJob{
+		ID:          newSyncJobID(),
+		TriggeredBy: trigger,
+		State:       entity.SyncRunStatusRunning,
+		StartedAt:   time.Now(),
+	}}
+
+	t.current = job
+	t.jobs[job.job.ID] = job
+	t.order = append(t.order, job.job.ID)
+	if len(t.order) > maxTrackedSyncJobs {
+		delete(t.jobs, t.order[0])
+		t.order = t.order[1:]
+	}
+
+	return job, true
+}
+
+// finish releases the running slot held by job.
+func (t *syncJobTracker) finish(job *syncJob) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current == job {
+		t.current = nil
+	}
+}
+
+// abandon forgets a job that never got to run.
+func (t *syncJobTracker) abandon(job *syncJob) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current == job {
+		t.current = nil
+	}
+	delete(t.jobs, job.job.ID)
+}
+
+func (t *syncJobTracker) get(id string) *syncJob {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.jobs[id]
+}
+
+func newSyncJobID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
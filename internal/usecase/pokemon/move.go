@@ -0,0 +1,201 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+// ErrMoveNotFound is returned when a move does not exist.
+var ErrMoveNotFound = errors.New("move not found")
+
+// GetPokemonMoves returns the learnset of a Pokemon.
+func (u *usecase) GetPokemonMoves(id uint, filter repository.PokemonMoveFilter) ([]*entity.PokemonMove, error) {
+	ctx := context.Background()
+
+	pokemon, err := u.pokemonRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, ErrPokemonNotFound
+	}
+
+	pokemonMoves, err := u.pokemonMoveRepo.GetByPokemonID(ctx, id, filter)
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon moves: %w", err)
+	}
+
+	return pokemonMoves, nil
+}
+
+// GetMoveLearners returns a move and a page of the Pokemon that can learn
+// it, along with how many there are in total and the ID of the Pokemon the
+// next page starts after, which is nil on the last page.
+func (u *usecase) GetMoveLearners(name string, filter repository.PokemonMoveFilter, limit, offset int) (*entity.Move, []*entity.Pokemon, int64, *uint, error) {
+	ctx := context.Background()
+
+	move, err := u.getMove(ctx, name)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	pokemons, total, err := u.pokemonMoveRepo.ListPokemonByMove(ctx, move.ID, filter, limit, offset)
+	if err != nil {
+		return nil, nil, 0, nil, fmt.Errorf("listing pokemon by move: %w", err)
+	}
+
+	var next *uint
+	if len(pokemons) > 0 && int64(offset+len(pokemons)) < total {
+		next = &pokemons[len(pokemons)-1].ID
+	}
+	return move, pokemons, total, next, nil
+}
+
+// GetMoveLearnersAfter is GetMoveLearners for the page that starts after
+// the Pokemon with ID afterID rather than at an offset.
+func (u *usecase) GetMoveLearnersAfter(name string, filter repository.PokemonMoveFilter, afterID uint, limit int) (*entity.Move, []*entity.Pokemon, int64, *uint, error) {
+	ctx := context.Background()
+
+	move, err := u.getMove(ctx, name)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+
+	// One Pokemon past the page tells whether there is a next page.
+	pokemons, total, err := u.pokemonMoveRepo.ListPokemonByMoveAfter(ctx, move.ID, filter, afterID, limit+1)
+	if err != nil {
+		return nil, nil, 0, nil, fmt.Errorf("listing pokemon by move: %w", err)
+	}
+
+	var next *uint
+	if len(pokemons) > limit {
+		pokemons = pokemons[:limit]
+		next = &pokemons[len(pokemons)-1].ID
+	}
+	return move, pokemons, total, next, nil
+}
+
+func (u *usecase) getMove(ctx context.Context, name string) (*entity.Move, error) {
+	move, err := u.moveRepo.GetByName(ctx, strings.ToLower(strings.TrimSpace(name)))
+	if err != nil {
+		return nil, fmt.Errorf("getting move: %w", err)
+	}
+	if move == nil {
+		return nil, ErrMoveNotFound
+	}
+	return move, nil
+}
+
+// syncMoves saves the moves a fetched Pokemon can learn that are not
+// stored yet, so that its learnset can reference them when the Pokemon is
+// saved. Moves hardly ever change upstream, so stored ones are not fetched
+// again. A move that cannot be saved is left out of the learnset rather
+// than failing the Pokemon, and the Pokemon is fetched in full next time so
+// that the move is retried.
+func (u *usecase) syncMoves(ctx context.Context, state *resourceSync, pokemonData *entity.PokemonAPIResponse) error {
+	ids := make([]uint, 0, len(pokemonData.Moves))
+	for _, pokemonMove := range pokemonData.Moves {
+		if moveID := pokemonMove.Move.ID(); moveID != 0 {
+			ids = append(ids, uint(moveID))
+		}
+	}
+	storedIDs, err := u.moveRepo.ListStoredIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	stored := make(map[int]bool, len(storedIDs))
+	for _, id := range storedIDs {
+		stored[int(id)] = true
+	}
+
+	moves := pokemonData.Moves[:0]
+	for _, pokemonMove := range pokemonData.Moves {
+		moveID := pokemonMove.Move.ID()
+		if moveID == 0 || stored[moveID] {
+			moves = append(moves, pokemonMove)
+			continue
+		}
+
+		err := state.moves.do(moveID, func() error {
+			moveData, err := u.pokemonAPIRepo.GetMove(ctx, moveID)
+			if err != nil {
+				return fmt.Errorf("fetching move %d: %w", moveID, err)
+			}
+
+			if err := u.moveRepo.CreateOrUpdate(ctx, convertAPIResponseToMove(moveData)); err != nil {
+				return fmt.Errorf("saving move %d: %w", moveID, err)
+			}
+
+			return nil
+		})
+		if errors.Is(err, repository.ErrStaleFencingToken) || ctx.Err() != nil {
+			return err
+		}
+		if err != nil {
+			log.Printf("⚠️ Leaving move %d out of the learnset of Pokemon %d: %v", moveID, pokemonData.ID, err)
+			u.pokemonAPIRepo.ForgetPokemon(pokemonData.ID)
+			continue
+		}
+		moves = append(moves, pokemonMove)
+	}
+	pokemonData.Moves = moves
+
+	return nil
+}
+
+func convertAPIResponseToMove(apiResponse *entity.MoveAPIResponse) *entity.Move {
+	return &entity.Move{
+		ID:          uint(apiResponse.ID),
+		Name:        apiResponse.Name,
+		Power:       apiResponse.Power,
+		Accuracy:    apiResponse.Accuracy,
+		PP:          apiResponse.PP,
+		DamageClass: apiResponse.DamageClass.Name,
+		TypeName:    apiResponse.Type.Name,
+	}
+}
+
+// convertPokemonMoves flattens the upstream learnset into one row per
+// move, learn method, level and version group.
+func convertPokemonMoves(pokemonID uint, moves []entity.PokemonMoveAPI) []entity.PokemonMove {
+	type learnKey struct {
+		moveID       uint
+		learnMethod  string
+		level        int
+		versionGroup string
+	}
+
+	var pokemonMoves []entity.PokemonMove
+	seen := make(map[learnKey]bool)
+	for _, moveAPI := range moves {
+		moveID := uint(moveAPI.Move.ID())
+		if moveID == 0 {
+			continue
+		}
+
+		for _, details := range moveAPI.VersionGroupDetails {
+			key := learnKey{moveID, details.MoveLearnMethod.Name, details.LevelLearnedAt, details.VersionGroup.Name}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			pokemonMoves = append(pokemonMoves, entity.PokemonMove{
+				PokemonID:    pokemonID,
+				MoveID:       moveID,
+				MoveName:     moveAPI.Move.Name,
+				LearnMethod:  key.learnMethod,
+				Level:        key.level,
+				VersionGroup: key.versionGroup,
+			})
+		}
+	}
+
+	return pokemonMoves
+}
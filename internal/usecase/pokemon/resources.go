@@ -0,0 +1,85 @@
+package pokemon
+
+import (
+	"sort"
+	"sync"
+)
+
+// resourceSync makes sure each species, evolution chain, move and ability
+// that Pokemon refer to is fetched and saved at most once per sync run:
+// the forms of a Pokemon share a species, a whole family shares a chain,
+// and most moves and abilities are shared by many Pokemon.
+type resourceSync struct {
+	species   onceGroup
+	chains    onceGroup
+	moves     onceGroup
+	abilities onceGroup
+
+	mu sync.Mutex
+	// varieties are the IDs of the non-default Pokemon of the species
+	// seen so far.
+	varieties map[int]bool
+}
+
+func newResourceSync() *resourceSync {
+	return &resourceSync{}
+}
+
+func (s *resourceSync) addVariety(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.varieties == nil {
+		s.varieties = make(map[int]bool)
+	}
+	s.varieties[id] = true
+}
+
+// varietyIDs returns the IDs passed to addVariety in ascending order.
+func (s *resourceSync) varietyIDs() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]int, 0, len(s.varieties))
+	for id := range s.varieties {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// onceGroup runs a function once per key. Callers that arrive while it is
+// running wait for it and share its error. A success is remembered for the
+// rest of the run; a failure is not, so the next caller with that key
+// tries again.
+type onceGroup struct {
+	mu    sync.Mutex
+	calls map[int]*onceCall
+}
+
+type onceCall struct {
+	done chan struct{}
+	err  error
+}
+
+func (g *onceGroup) do(key int, fn func() error) error {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[int]*onceCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.err
+	}
+	call := &onceCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.err = fn()
+	if call.err != nil {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+	}
+	close(call.done)
+	return call.err
+}
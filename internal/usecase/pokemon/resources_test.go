@@ -0,0 +1,140 @@
+package pokemon
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnceGroup(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	tests := []struct {
+		name      string
+		results   []error
+		wantCalls int
+		wantErrs  []error
+	}{
+		{
+			name:      "success is remembered",
+			results:   []error{nil},
+			wantCalls: 1,
+			wantErrs:  []error{nil, nil, nil},
+		},
+		{
+			name:      "failure is retried",
+			results:   []error{errFetch, nil},
+			wantCalls: 2,
+			wantErrs:  []error{errFetch, nil, nil},
+		},
+		{
+			name:      "every failure is retried",
+			results:   []error{errFetch, errFetch, errFetch},
+			wantCalls: 3,
+			wantErrs:  []error{errFetch, errFetch, errFetch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var group onceGroup
+			calls := 0
+			for i, want := range tt.wantErrs {
+				err := group.do(1, func() error {
+					calls++
+					return tt.results[calls-1]
+				})
+				if !errors.Is(err, want) {
+					t.Errorf("call %d: error = %v, want %v", i, err, want)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("fn ran %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestOnceGroupKeysAreIndependent(t *testing.T) {
+	var group onceGroup
+	calls := make(map[int]int)
+	for _, key := range []int{1, 2, 1, 2, 3} {
+		group.do(key, func() error {
+			calls[key]++
+			return nil
+		})
+	}
+
+	for _, key := range []int{1, 2, 3} {
+		if calls[key] != 1 {
+			t.Errorf("key %d ran %d times, want 1", key, calls[key])
+		}
+	}
+}
+
+func TestOnceGroupWaitersShareTheRunningCall(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	for _, result := range []error{nil, errFetch} {
+		var group onceGroup
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		first := make(chan error, 1)
+		go func() {
+			first <- group.do(1, func() error {
+				close(started)
+				<-release
+				return result
+			})
+		}()
+		<-started
+
+		// Callers that arrive while the first call runs must wait for it.
+		// Any that arrive after a failure has been cleared retry on their
+		// own, and this retry succeeds.
+		const waiters = 5
+		var wg sync.WaitGroup
+		var retries atomic.Int32
+		errs := make(chan error, waiters)
+		for i := 0; i < waiters; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- group.do(1, func() error {
+					select {
+					case <-release:
+					default:
+						t.Error("fn ran while the first call was running")
+					}
+					retries.Add(1)
+					return nil
+				})
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		close(errs)
+
+		if err := <-first; !errors.Is(err, result) {
+			t.Errorf("first call: error = %v, want %v", err, result)
+		}
+		for err := range errs {
+			if err != nil && !errors.Is(err, result) {
+				t.Errorf("waiter: error = %v, want %v or nil", err, result)
+			}
+		}
+		// After a success nothing runs again; after a failure one waiter
+		// retries and its success is remembered for the rest
+		wantRetries := int32(0)
+		if result != nil {
+			wantRetries = 1
+		}
+		if got := retries.Load(); got > wantRetries {
+			t.Errorf("with result %v: fn ran %d more times, want at most %d", result, got, wantRetries)
+		}
+	}
+}
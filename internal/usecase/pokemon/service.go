@@ -2,9 +2,32 @@ package pokemon
 
 import (
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
 )
 
 type Service interface {
-	SyncPokemonData() error
-	GetPokemonItems() ([]*entity.Pokemon, int64, error)
-}
\ No newline at end of file
+	SyncPokemonData(trigger entity.SyncTrigger) error
+	StartSyncJob(trigger entity.SyncTrigger) (*entity.SyncJob, bool, error)
+	GetSyncJob(id string) *entity.SyncJob
+	SyncSinglePokemon(idOrName string) (*entity.Pokemon, entity.UpsertResult, error)
+	DryRunSync(pokemonIDs []int) (*entity.SyncDryRun, error)
+	GetPokemonItems(language string, filter repository.PokemonFilter, order repository.PokemonSort, limit, offset int) ([]*entity.Pokemon, int64, *repository.PokemonCursor, error)
+	GetPokemonItemsAfter(language string, filter repository.PokemonFilter, order repository.PokemonSort, after repository.PokemonCursor, limit int) ([]*entity.Pokemon, int64, *repository.PokemonCursor, error)
+	GetPokemonByID(language string, id uint) (*entity.Pokemon, error)
+	GetPokemonByName(language, name string) (*entity.Pokemon, error)
+	GetPokemonSprite(id uint, variant string) (*entity.Sprite, error)
+	GetPokemonEvolutions(id uint, language string) (*entity.EvolutionChain, error)
+	GetPokemonMoves(id uint, filter repository.PokemonMoveFilter) ([]*entity.PokemonMove, error)
+	GetMoveLearners(name string, filter repository.PokemonMoveFilter, limit, offset int) (*entity.Move, []*entity.Pokemon, int64, *uint, error)
+	GetMoveLearnersAfter(name string, filter repository.PokemonMoveFilter, afterID uint, limit int) (*entity.Move, []*entity.Pokemon, int64, *uint, error)
+	ListAbilities(language string, limit, offset int) ([]*entity.Ability, int64, *string, error)
+	ListAbilitiesAfter(language, after string, limit int) ([]*entity.Ability, int64, *string, error)
+	GetAbility(name, language string, limit, offset int) (*entity.Ability, []*entity.PokemonAbility, int64, error)
+	GetTypeMatchups(name, language string) (*entity.Type, []*entity.TypeDamageRelation, error)
+	CalculateMatchup(attackingType, defendingPokemon string, defendingTypes []string) (*entity.Matchup, error)
+	ListTombstonedPokemon(limit, offset int) ([]*entity.Pokemon, int64, error)
+	RestorePokemon(id uint) (*entity.Pokemon, error)
+	GetPokemonHistory(id uint, limit, offset int) ([]*entity.PokemonHistory, int64, error)
+	ListSyncRuns(limit, offset int) ([]*entity.SyncRun, int64, error)
+	GetSyncRun(id uint) (*entity.SyncRun, error)
+}
@@ -0,0 +1,100 @@
+package pokemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+// ErrInvalidSpriteVariant is returned for a variant that is not one of
+// entity.SpriteVariants.
+var ErrInvalidSpriteVariant = errors.New("invalid sprite variant")
+
+// ErrSpriteNotFound is returned when a Pokemon has no image for a variant.
+var ErrSpriteNotFound = errors.New("sprite not found")
+
+// GetPokemonSprite returns a sprite image, downloading it from upstream the
+// first time it is requested and serving the stored copy after that.
+func (u *usecase) GetPokemonSprite(id uint, variant string) (*entity.Sprite, error) {
+	ctx := context.Background()
+
+	pokemon, err := u.pokemonRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, ErrPokemonNotFound
+	}
+
+	spriteURL, ok := pokemon.Sprites.URL(variant)
+	if !ok {
+		return nil, ErrInvalidSpriteVariant
+	}
+	if spriteURL == "" {
+		return nil, ErrSpriteNotFound
+	}
+
+	key := spriteKey(spriteURL)
+	sprite := &entity.Sprite{Key: key}
+
+	data, err := u.sprites.Get(ctx, key)
+	if err == nil {
+		sprite.Data = data
+		sprite.ContentType = spriteContentType(key, data)
+		return sprite, nil
+	}
+	if !errors.Is(err, repository.ErrBlobNotFound) {
+		log.Printf("⚠️ Failed to read stored sprite %s: %v", key, err)
+	}
+
+	log.Printf("Downloading %s sprite for Pokemon ID %d", variant, id)
+	data, err = u.pokemonAPIRepo.DownloadSprite(ctx, spriteURL)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrSpriteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("downloading sprite: %w", err)
+	}
+
+	if err := u.sprites.Put(ctx, key, data); err != nil {
+		log.Printf("⚠️ Failed to store sprite %s: %v", key, err)
+	}
+
+	sprite.Data = data
+	sprite.ContentType = spriteContentType(key, data)
+	return sprite, nil
+}
+
+// spriteKey names a stored sprite after a hash of its upstream URL, so a
+// new upstream image is stored under a new key. The URL's extension is kept
+// to recover the content type.
+func spriteKey(spriteURL string) string {
+	sum := sha256.Sum256([]byte(spriteURL))
+	key := hex.EncodeToString(sum[:])
+
+	if parsed, err := url.Parse(spriteURL); err == nil {
+		if ext := strings.ToLower(path.Ext(parsed.Path)); ext != "" && len(ext) <= 5 {
+			key += ext
+		}
+	}
+
+	return key
+}
+
+func spriteContentType(key string, data []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
@@ -0,0 +1,504 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+// syncLockName names the cluster-wide lock held for the duration of a sync.
+const syncLockName = "pokemon-sync"
+
+func (u *usecase) SyncPokemonData(trigger entity.SyncTrigger) error {
+	ctx := context.Background()
+
+	job, started := u.jobs.start(trigger)
+	if !started {
+		return ErrSyncInProgress
+	}
+
+	lock, token, err := u.acquireSyncLock(ctx)
+	if err != nil {
+		u.jobs.abandon(job)
+		return err
+	}
+
+	return u.runSync(ctx, job, lock, token)
+}
+
+// SyncSinglePokemon refreshes one Pokemon from upstream and invalidates
+// only the cache entries that can contain it. It holds the cluster-wide
+// sync lock while it runs, so it fails with ErrSyncInProgress during a
+// full sync, and stops with ErrSyncLeaseLost if it loses the lease.
+func (u *usecase) SyncSinglePokemon(idOrName string) (*entity.Pokemon, entity.UpsertResult, error) {
+	ctx := context.Background()
+	idOrName = strings.ToLower(strings.TrimSpace(idOrName))
+
+	lock, token, err := u.acquireSyncLock(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		if err := lock.Release(ctx); err != nil {
+			log.Printf("Warning: failed to release sync lock: %v", err)
+		}
+	}()
+	writeCtx, abort := watchSyncLease(repository.WithFencingToken(ctx, syncLockName, token), lock, "sync of Pokemon "+idOrName)
+	defer abort(nil)
+
+	log.Printf("Syncing single Pokemon: %s", idOrName)
+
+	pokemonData, err := u.pokemonAPIRepo.LookupPokemon(writeCtx, idOrName)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, "", ErrPokemonNotFound
+	}
+	if err != nil {
+		err = leaseLostOr(writeCtx, err)
+		log.Printf("❌ Error fetching Pokemon %s: %v", idOrName, err)
+		return nil, "", fmt.Errorf("fetching pokemon: %w", err)
+	}
+
+	if err := u.syncRelated(writeCtx, newResourceSync(), pokemonData); err != nil {
+		err = leaseLostOr(writeCtx, err)
+		log.Printf("❌ Error syncing resources of Pokemon %s: %v", idOrName, err)
+		return nil, "", err
+	}
+
+	pokemon := convertAPIResponseToPokemon(pokemonData)
+
+	outcome, err := u.pokemonRepo.CreateOrUpdate(writeCtx, pokemon)
+	if err != nil {
+		err = leaseLostOr(writeCtx, err)
+		u.pokemonAPIRepo.ForgetPokemon(pokemonData.ID)
+		log.Printf("❌ Error saving Pokemon %s: %v", idOrName, err)
+		return nil, "", fmt.Errorf("saving pokemon: %w", err)
+	}
+
+	if outcome != entity.UpsertResultUnchanged {
+		u.invalidatePokemonCache(ctx, pokemon)
+	}
+
+	saved, err := u.pokemonRepo.GetByNameWithRelations(ctx, pokemon.Name)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching saved pokemon: %w", err)
+	}
+
+	log.Printf("✅ Successfully synced Pokemon: %s (%s)", pokemon.Name, outcome)
+	return saved, outcome, nil
+}
+
+func (u *usecase) StartSyncJob(trigger entity.SyncTrigger) (*entity.SyncJob, bool, error) {
+	ctx := context.Background()
+
+	job, started := u.jobs.start(trigger)
+	if !started {
+		return job.snapshot(), false, nil
+	}
+
+	// Take the lock before answering so the caller learns right away when
+	// another instance is already syncing.
+	lock, token, err := u.acquireSyncLock(ctx)
+	if err != nil {
+		u.jobs.abandon(job)
+		return nil, false, err
+	}
+
+	go u.runSync(ctx, job, lock, token)
+
+	return job.snapshot(), true, nil
+}
+
+func (u *usecase) GetSyncJob(id string) *entity.SyncJob {
+	job := u.jobs.get(id)
+	if job == nil {
+		return nil
+	}
+	return job.snapshot()
+}
+
+// acquireSyncLock takes the cluster-wide sync lock and advances the write
+// fence, so writes from any previous holder are rejected. It returns the
+// new fencing token for the holder's writes.
+func (u *usecase) acquireSyncLock(ctx context.Context) (repository.Lock, int64, error) {
+	lock, err := u.lockRepo.Acquire(ctx, syncLockName, u.syncConfig.LockTTL)
+	if errors.Is(err, repository.ErrLockHeld) {
+		log.Println("⏭️ Pokemon data sync already running on another instance")
+		return nil, 0, ErrSyncInProgress
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("acquiring sync lock: %w", err)
+	}
+
+	token, err := u.fenceRepo.Advance(ctx, syncLockName)
+	if err != nil {
+		lock.Release(ctx)
+		return nil, 0, fmt.Errorf("advancing sync fence: %w", err)
+	}
+
+	log.Printf("🔒 Acquired sync lock with fencing token %d", token)
+	return lock, token, nil
+}
+
+// watchSyncLease returns a context that is canceled with ErrSyncLeaseLost
+// as soon as the lease on lock is lost, so that work done under the lock
+// stops rather than only failing the fence check when it writes. The work
+// is named in the log message.
+func watchSyncLease(ctx context.Context, lock repository.Lock, work string) (context.Context, context.CancelCauseFunc) {
+	leaseCtx, abort := context.WithCancelCause(ctx)
+	go func() {
+		select {
+		case <-lock.Lost():
+			log.Printf("⚠️ Sync lease lost, aborting %s", work)
+			abort(ErrSyncLeaseLost)
+		case <-leaseCtx.Done():
+		}
+	}()
+	return leaseCtx, abort
+}
+
+// leaseLostOr returns ErrSyncLeaseLost when ctx was canceled by
+// watchSyncLease, and err otherwise.
+func leaseLostOr(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+	return err
+}
+
+// runSync performs a full sync on behalf of job while holding lock,
+// keeping the job's progress and the persisted sync run up to date. Its
+// writes carry token, and it is aborted as soon as the lease on lock is
+// lost.
+func (u *usecase) runSync(ctx context.Context, job *syncJob, lock repository.Lock, token int64) error {
+	defer u.jobs.finish(job)
+	defer func() {
+		if err := lock.Release(ctx); err != nil {
+			log.Printf("Warning: failed to release sync lock: %v", err)
+		}
+	}()
+
+	trigger := job.snapshot().TriggeredBy
+	log.Printf("Starting Pokemon data sync (triggered by %s)...", trigger)
+
+	writeCtx := repository.WithFencingToken(ctx, syncLockName, token)
+	run := u.startSyncRun(ctx, trigger)
+	if run != nil {
+		job.update(func(j *entity.SyncJob) { j.RunID = run.ID })
+		// Pokemon updated by this sync record it in their change log
+		writeCtx = repository.WithSyncRunID(writeCtx, run.ID)
+	}
+
+	syncCtx, abort := watchSyncLease(writeCtx, lock, "Pokemon data sync")
+	defer abort(nil)
+
+	pokemonIDs, listedIDs, err := u.listPokemonIDs(syncCtx)
+	if err != nil {
+		err = leaseLostOr(syncCtx, err)
+		log.Printf("❌ Pokemon data sync FAILED: %v", err)
+		err = fmt.Errorf("listing upstream pokemon: %w", err)
+		u.finishSyncRun(ctx, run, job, err)
+		return err
+	}
+
+	log.Printf("Found %d Pokemon to sync using %d worker(s)", len(pokemonIDs), u.syncConfig.Concurrency)
+	job.update(func(j *entity.SyncJob) { j.Total = len(pokemonIDs) })
+
+	resources := newResourceSync()
+	u.syncPokemonIDs(syncCtx, abort, job, resources, pokemonIDs)
+	if context.Cause(syncCtx) == nil {
+		u.syncVarieties(syncCtx, abort, job, resources, pokemonIDs)
+	}
+
+	// Damage relations do not affect the run's outcome; they are retried
+	// in full on the next run.
+	if context.Cause(syncCtx) == nil {
+		if err := u.syncTypes(syncCtx); err != nil {
+			log.Printf("⚠️ Failed to sync type damage relations: %v", err)
+		}
+		u.tombstoneMissingPokemon(syncCtx, listedIDs)
+	}
+
+	if err := u.cache.DeleteByPattern(ctx, "pokemon:*"); err != nil {
+		log.Printf("Warning: failed to invalidate cache: %v", err)
+	}
+
+	progress := job.snapshot()
+	if cause := context.Cause(syncCtx); cause != nil {
+		log.Printf("❌ Pokemon data sync ABORTED after %d of %d: %v", progress.Processed, progress.Total, cause)
+		err = cause
+	} else if progress.ErrorCount > 0 && progress.SuccessCount+progress.SkipCount == 0 {
+		log.Printf("❌ Pokemon data sync FAILED: 0 success, %d errors", progress.ErrorCount)
+		err = fmt.Errorf("all pokemon sync attempts failed")
+	} else if progress.ErrorCount > 0 {
+		log.Printf("⚠️ Pokemon data sync PARTIAL: %d success, %d skipped, %d errors", progress.SuccessCount, progress.SkipCount, progress.ErrorCount)
+	} else {
+		log.Printf("✅ Pokemon data sync COMPLETED: %d success, %d skipped, 0 errors", progress.SuccessCount, progress.SkipCount)
+	}
+
+	u.finishSyncRun(ctx, run, job, err)
+	return err
+}
+
+func (u *usecase) ListSyncRuns(limit, offset int) ([]*entity.SyncRun, int64, error) {
+	ctx := context.Background()
+
+	runs, err := u.syncRunRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetching sync runs: %w", err)
+	}
+
+	total, err := u.syncRunRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting sync runs: %w", err)
+	}
+
+	return runs, total, nil
+}
+
+func (u *usecase) GetSyncRun(id uint) (*entity.SyncRun, error) {
+	run, err := u.syncRunRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sync run: %w", err)
+	}
+	return run, nil
+}
+
+// startSyncRun records the beginning of a sync. History is best effort: a
+// failure to record it is logged and never stops the sync itself.
+func (u *usecase) startSyncRun(ctx context.Context, trigger entity.SyncTrigger) *entity.SyncRun {
+	run := &entity.SyncRun{
+		TriggeredBy: trigger,
+		Status:      entity.SyncRunStatusRunning,
+		StartedAt:   time.Now(),
+	}
+
+	if err := u.syncRunRepo.Create(ctx, run); err != nil {
+		log.Printf("Warning: failed to record sync run: %v", err)
+		return nil
+	}
+
+	return run
+}
+
+// finishSyncRun stores the final outcome on both the in-memory job and the
+// persisted run.
+func (u *usecase) finishSyncRun(ctx context.Context, run *entity.SyncRun, job *syncJob, syncErr error) {
+	finishedAt := time.Now()
+
+	job.update(func(j *entity.SyncJob) {
+		switch {
+		case syncErr != nil:
+			j.State = entity.SyncRunStatusFailed
+			j.Error = syncErr.Error()
+		case j.ErrorCount > 0:
+			j.State = entity.SyncRunStatusPartial
+		default:
+			j.State = entity.SyncRunStatusCompleted
+		}
+		j.FinishedAt = &finishedAt
+	})
+
+	if run == nil {
+		return
+	}
+
+	progress := job.snapshot()
+	run.FinishedAt = &finishedAt
+	run.Status = progress.State
+	run.Error = progress.Error
+	run.SuccessCount = progress.SuccessCount
+	run.SkipCount = progress.SkipCount
+	run.ErrorCount = progress.ErrorCount
+	run.Failures = progress.Failures
+
+	if err := u.syncRunRepo.Update(ctx, run); err != nil {
+		log.Printf("Warning: failed to update sync run %d: %v", run.ID, err)
+	}
+}
+
+// invalidatePokemonCache drops the cache entries that can contain pokemon:
+// its own detail entries and the list pages it may appear on. The detail
+// keys are known, so they are deleted one by one rather than scanned for.
+func (u *usecase) invalidatePokemonCache(ctx context.Context, pokemon *entity.Pokemon) {
+	for _, language := range u.languages {
+		for _, key := range []string{pokemonIDCacheKey(pokemon.ID, language), pokemonNameCacheKey(pokemon.Name, language)} {
+			if err := u.cache.Delete(ctx, key); err != nil {
+				log.Printf("Warning: failed to invalidate cache key %s: %v", key, err)
+			}
+		}
+	}
+
+	if err := u.cache.DeleteByPattern(ctx, "pokemon:list*"); err != nil {
+		log.Printf("Warning: failed to invalidate list cache: %v", err)
+	}
+}
+
+// listPokemonIDs walks the paginated upstream listing and returns the IDs
+// that fall inside the configured range and allow-list, along with every
+// ID listed.
+func (u *usecase) listPokemonIDs(ctx context.Context) ([]int, []uint, error) {
+	allowed := make(map[int]bool, len(u.syncConfig.IDs))
+	for _, id := range u.syncConfig.IDs {
+		allowed[id] = true
+	}
+
+	var ids []int
+	var listed []uint
+	for offset := 0; ; offset += u.syncConfig.PageSize {
+		page, err := u.pokemonAPIRepo.ListPokemon(ctx, u.syncConfig.PageSize, offset)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetching page at offset %d: %w", offset, err)
+		}
+
+		for _, resource := range page.Results {
+			id := resource.ID()
+			if id != 0 {
+				listed = append(listed, uint(id))
+			}
+			if id == 0 || id < u.syncConfig.StartID {
+				continue
+			}
+			if u.syncConfig.EndID > 0 && id > u.syncConfig.EndID {
+				continue
+			}
+			if len(allowed) > 0 && !allowed[id] {
+				continue
+			}
+			ids = append(ids, id)
+		}
+
+		if page.Next == "" || len(page.Results) == 0 {
+			break
+		}
+	}
+
+	return ids, listed, nil
+}
+
+// syncPokemonIDs syncs the IDs through the worker pool and records each
+// outcome on job as it comes in. A write rejected by the fence means
+// another instance has taken over, so it aborts the whole pass.
+func (u *usecase) syncPokemonIDs(ctx context.Context, abort context.CancelCauseFunc, job *syncJob, resources *resourceSync, pokemonIDs []int) {
+	u.forEachPokemonID(ctx, pokemonIDs, func(id int) {
+		outcome, err := u.syncPokemon(ctx, resources, id)
+		if errors.Is(err, repository.ErrStaleFencingToken) {
+			abort(ErrSyncLeaseLost)
+		}
+
+		job.update(func(j *entity.SyncJob) {
+			j.Processed++
+			switch {
+			case err != nil:
+				j.ErrorCount++
+				j.Failures = append(j.Failures, entity.SyncFailure{PokemonID: id, Reason: err.Error()})
+			case outcome == entity.UpsertResultUnchanged:
+				j.SkipCount++
+			default:
+				j.SuccessCount++
+			}
+		})
+	})
+
+	// Workers finish in any order; keep the failures in ID order so the
+	// outcome matches a serial run.
+	job.update(func(j *entity.SyncJob) {
+		sort.Slice(j.Failures, func(a, b int) bool {
+			return j.Failures[a].PokemonID < j.Failures[b].PokemonID
+		})
+	})
+}
+
+// forEachPokemonID fans the IDs out to a bounded pool of workers and waits
+// for them to finish. The upstream rate limit is enforced by the API
+// repository, which all workers share. No new IDs are handed out once ctx
+// is done.
+func (u *usecase) forEachPokemonID(ctx context.Context, pokemonIDs []int, fn func(id int)) {
+	workers := u.syncConfig.Concurrency
+	if workers > len(pokemonIDs) {
+		workers = len(pokemonIDs)
+	}
+
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				fn(id)
+			}
+		}()
+	}
+
+dispatch:
+	for _, id := range pokemonIDs {
+		select {
+		case jobs <- id:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// syncRelated saves the species, evolution chain, moves and abilities of a
+// fetched Pokemon, which must exist before the Pokemon can be saved.
+func (u *usecase) syncRelated(ctx context.Context, resources *resourceSync, pokemonData *entity.PokemonAPIResponse) error {
+	if err := u.syncSpecies(ctx, resources, pokemonData); err != nil {
+		return fmt.Errorf("syncing species: %w", err)
+	}
+	if err := u.syncMoves(ctx, resources, pokemonData); err != nil {
+		return fmt.Errorf("syncing moves: %w", err)
+	}
+	if err := u.syncAbilities(ctx, resources, pokemonData); err != nil {
+		return fmt.Errorf("syncing abilities: %w", err)
+	}
+	return nil
+}
+
+// syncPokemon fetches a single Pokemon from upstream and saves it along
+// with the resources it refers to.
+func (u *usecase) syncPokemon(ctx context.Context, resources *resourceSync, id int) (entity.UpsertResult, error) {
+	log.Printf("Fetching Pokemon ID: %d", id)
+
+	pokemonData, err := u.pokemonAPIRepo.GetPokemon(ctx, id)
+	if errors.Is(err, repository.ErrNotModified) {
+		log.Printf("⚡ Pokemon ID %d not modified upstream, skipping", id)
+		return entity.UpsertResultUnchanged, nil
+	}
+	if err != nil {
+		log.Printf("❌ Error fetching Pokemon ID %d: %v", id, err)
+		return "", fmt.Errorf("fetching pokemon: %w", err)
+	}
+
+	if err := u.syncRelated(ctx, resources, pokemonData); err != nil {
+		// The Pokemon itself is not saved either, so it must be fetched in
+		// full next time rather than answered with a 304.
+		u.pokemonAPIRepo.ForgetPokemon(id)
+		log.Printf("❌ Error syncing resources of Pokemon ID %d: %v", id, err)
+		return "", err
+	}
+
+	pokemon := convertAPIResponseToPokemon(pokemonData)
+
+	outcome, err := u.pokemonRepo.CreateOrUpdate(ctx, pokemon)
+	if err != nil {
+		// Without this the next sync would get a 304 and never retry the save.
+		u.pokemonAPIRepo.ForgetPokemon(id)
+		log.Printf("❌ Error saving Pokemon ID %d: %v", id, err)
+		return "", fmt.Errorf("saving pokemon: %w", err)
+	}
+
+	log.Printf("✅ Successfully synced Pokemon: %s (%s)", pokemon.Name, outcome)
+	return outcome, nil
+}
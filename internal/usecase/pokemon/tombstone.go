@@ -0,0 +1,67 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+// tombstoneMissingPokemon soft deletes the stored Pokemon that are no
+// longer in the upstream listing. An empty listing is taken as an upstream
+// fault rather than every Pokemon being gone.
+func (u *usecase) tombstoneMissingPokemon(ctx context.Context, listedIDs []uint) {
+	if len(listedIDs) == 0 {
+		log.Println("⚠️ Upstream listed no Pokemon, skipping tombstones")
+		return
+	}
+
+	tombstoned, err := u.pokemonRepo.TombstoneMissing(ctx, listedIDs)
+	if err != nil {
+		log.Printf("⚠️ Failed to tombstone Pokemon missing upstream: %v", err)
+		return
+	}
+
+	for _, pokemon := range tombstoned {
+		log.Printf("🪦 Pokemon ID %d (%s) is gone upstream, tombstoned", pokemon.ID, pokemon.Name)
+	}
+}
+
+// ListTombstonedPokemon returns a page of the Pokemon tombstoned because
+// they disappeared upstream, along with how many there are in total.
+func (u *usecase) ListTombstonedPokemon(limit, offset int) ([]*entity.Pokemon, int64, error) {
+	ctx := context.Background()
+
+	pokemons, err := u.pokemonRepo.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetching tombstoned pokemons: %w", err)
+	}
+
+	total, err := u.pokemonRepo.CountDeleted(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting tombstoned pokemons: %w", err)
+	}
+
+	return pokemons, total, nil
+}
+
+// RestorePokemon clears the tombstone of a Pokemon. It returns
+// ErrPokemonNotFound when no tombstoned Pokemon has that ID. A Pokemon that
+// is still gone upstream is tombstoned again by the next full sync.
+func (u *usecase) RestorePokemon(id uint) (*entity.Pokemon, error) {
+	ctx := context.Background()
+
+	pokemon, err := u.pokemonRepo.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("restoring pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, ErrPokemonNotFound
+	}
+
+	log.Printf("♻️ Pokemon ID %d (%s) restored", pokemon.ID, pokemon.Name)
+	u.invalidatePokemonCache(ctx, pokemon)
+
+	return pokemon, nil
+}
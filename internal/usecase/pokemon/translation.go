@@ -0,0 +1,49 @@
+package pokemon
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+// withLanguage returns a context that makes repositories load translations
+// in language and in the fallback language.
+func withLanguage(ctx context.Context, language string) context.Context {
+	if language == "" || language == entity.DefaultLanguage {
+		return repository.WithLanguages(ctx, entity.DefaultLanguage)
+	}
+	return repository.WithLanguages(ctx, language, entity.DefaultLanguage)
+}
+
+// convertTranslations merges the localized names and flavor texts of a
+// resource into one translation per language that has a name. Upstream
+// lists flavor texts oldest game first, so the last one of each language
+// is kept.
+func convertTranslations(names []entity.NameAPI, flavorTexts []entity.FlavorTextAPI) []entity.Translation {
+	descriptions := make(map[string]string)
+	for _, entry := range flavorTexts {
+		descriptions[entry.Language.Name] = normalizeFlavorText(entry.FlavorText)
+	}
+
+	translations := make([]entity.Translation, 0, len(names))
+	for _, name := range names {
+		translations = append(translations, entity.Translation{
+			Language:    name.Language.Name,
+			Name:        name.Name,
+			Description: descriptions[name.Language.Name],
+		})
+	}
+	sort.Slice(translations, func(i, j int) bool {
+		return translations[i].Language < translations[j].Language
+	})
+	return translations
+}
+
+// normalizeFlavorText joins the lines of a flavor text, which upstream
+// breaks as they were laid out in the games.
+func normalizeFlavorText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,195 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+)
+
+var (
+	// ErrTypeNotFound is returned when a type does not exist.
+	ErrTypeNotFound = errors.New("type not found")
+	// ErrDamageRelationsNotSynced is returned when a type exists but its
+	// damage relations have not been synced yet. A synced type may have no
+	// relations at all.
+	ErrDamageRelationsNotSynced = errors.New("type damage relations not synced")
+	// ErrInvalidMatchup is returned when a matchup does not name a
+	// defender with one or two types.
+	ErrInvalidMatchup = errors.New("invalid matchup")
+)
+
+// GetTypeMatchups returns a type and the damage relations it takes part in,
+// attacking or defending.
+func (u *usecase) GetTypeMatchups(name, language string) (*entity.Type, []*entity.TypeDamageRelation, error) {
+	ctx := withLanguage(context.Background(), language)
+
+	pokemonType, err := u.getType(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pokemonType.DamageRelationsSyncedAt == nil {
+		return nil, nil, ErrDamageRelationsNotSynced
+	}
+
+	relations, err := u.typeRepo.GetDamageRelations(ctx, pokemonType.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting damage relations: %w", err)
+	}
+
+	return pokemonType, relations, nil
+}
+
+// CalculateMatchup returns the damage multiplier of an attacking type
+// against either a stored Pokemon, given by ID or name, or one or two
+// defending types.
+func (u *usecase) CalculateMatchup(attackingType, defendingPokemon string, defendingTypes []string) (*entity.Matchup, error) {
+	ctx := context.Background()
+
+	attacker, err := u.getType(ctx, attackingType)
+	if err != nil {
+		return nil, err
+	}
+
+	matchup := &entity.Matchup{AttackingType: attacker.Name}
+
+	var defenders []string
+	if defendingPokemon != "" {
+		pokemon, err := u.getPokemonByIDOrName(ctx, defendingPokemon)
+		if err != nil {
+			return nil, err
+		}
+		matchup.DefendingPokemon = pokemon
+		for _, pokemonType := range pokemon.Types {
+			defenders = append(defenders, pokemonType.Type.Name)
+		}
+	} else {
+		for _, name := range defendingTypes {
+			defender, err := u.getType(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			defenders = append(defenders, defender.Name)
+		}
+	}
+	if len(defenders) == 0 || len(defenders) > 2 {
+		return nil, fmt.Errorf("%w: the defender must have one or two types, got %d", ErrInvalidMatchup, len(defenders))
+	}
+
+	if attacker.DamageRelationsSyncedAt == nil {
+		return nil, ErrDamageRelationsNotSynced
+	}
+
+	relations, err := u.typeRepo.GetDamageRelations(ctx, attacker.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting damage relations: %w", err)
+	}
+
+	multipliers := make(map[string]float64)
+	for _, relation := range relations {
+		if relation.AttackingTypeID == attacker.ID {
+			multipliers[relation.DefendingType.Name] = relation.Multiplier
+		}
+	}
+
+	matchup.Multiplier = 1
+	for _, defender := range defenders {
+		multiplier, ok := multipliers[defender]
+		if !ok {
+			multiplier = 1
+		}
+		matchup.DefendingTypes = append(matchup.DefendingTypes, entity.TypeMultiplier{Type: defender, Multiplier: multiplier})
+		matchup.Multiplier *= multiplier
+	}
+
+	return matchup, nil
+}
+
+func (u *usecase) getType(ctx context.Context, name string) (*entity.Type, error) {
+	pokemonType, err := u.typeRepo.GetByName(ctx, strings.ToLower(strings.TrimSpace(name)))
+	if err != nil {
+		return nil, fmt.Errorf("getting type: %w", err)
+	}
+	if pokemonType == nil {
+		return nil, ErrTypeNotFound
+	}
+	return pokemonType, nil
+}
+
+func (u *usecase) getPokemonByIDOrName(ctx context.Context, idOrName string) (*entity.Pokemon, error) {
+	idOrName = strings.ToLower(strings.TrimSpace(idOrName))
+
+	var (
+		pokemon *entity.Pokemon
+		err     error
+	)
+	if id, convErr := strconv.ParseUint(idOrName, 10, 64); convErr == nil {
+		pokemon, err = u.pokemonRepo.GetByIDWithRelations(ctx, uint(id))
+	} else {
+		pokemon, err = u.pokemonRepo.GetByNameWithRelations(ctx, idOrName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting pokemon: %w", err)
+	}
+	if pokemon == nil {
+		return nil, ErrPokemonNotFound
+	}
+	return pokemon, nil
+}
+
+// syncTypes fetches every upstream type and replaces the damage relations
+// and localized names stored for it. Relations are stored from the
+// attacking side only; the "from" lists upstream are the same relations
+// seen from the defender.
+func (u *usecase) syncTypes(ctx context.Context) error {
+	types, err := u.pokemonAPIRepo.ListTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("listing upstream types: %w", err)
+	}
+
+	for _, resource := range types {
+		typeData, err := u.pokemonAPIRepo.GetType(ctx, resource.Name)
+		if err != nil {
+			return fmt.Errorf("fetching type %s: %w", resource.Name, err)
+		}
+
+		if err := u.typeRepo.ReplaceDamageRelations(ctx, typeData.Name, convertDamageRelations(typeData.DamageRelations)); err != nil {
+			return fmt.Errorf("saving damage relations of type %s: %w", typeData.Name, err)
+		}
+
+		var translations []entity.TypeTranslation
+		for _, translation := range convertTranslations(typeData.Names, nil) {
+			translations = append(translations, entity.TypeTranslation{Translation: translation})
+		}
+		if err := u.typeRepo.SaveTranslations(ctx, typeData.Name, translations); err != nil {
+			return fmt.Errorf("saving translations of type %s: %w", typeData.Name, err)
+		}
+	}
+
+	log.Printf("Synced damage relations of %d types", len(types))
+	return nil
+}
+
+// convertDamageRelations maps each defending type to the multiplier of
+// attacks against it.
+func convertDamageRelations(relations entity.TypeDamageRelationsAPI) map[string]float64 {
+	multipliers := make(map[string]float64)
+	for _, group := range []struct {
+		types      []entity.NamedAPIResource
+		multiplier float64
+	}{
+		{relations.DoubleDamageTo, entity.DamageMultiplierDouble},
+		{relations.HalfDamageTo, entity.DamageMultiplierHalf},
+		{relations.NoDamageTo, entity.DamageMultiplierNone},
+	} {
+		for _, defender := range group.types {
+			multipliers[defender.Name] = group.multiplier
+		}
+	}
+	return multipliers
+}
@@ -0,0 +1,231 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AhmadNizar/cata-dtc/internal/entity"
+	"github.com/AhmadNizar/cata-dtc/internal/repository"
+)
+
+// fakeTypeRepository serves types by name and their damage relations by
+// type ID. Methods the tests do not use are left to the nil interface.
+type fakeTypeRepository struct {
+	repository.TypeRepository
+	types     map[string]*entity.Type
+	relations map[uint][]*entity.TypeDamageRelation
+}
+
+func (r *fakeTypeRepository) GetByName(ctx context.Context, name string) (*entity.Type, error) {
+	return r.types[name], nil
+}
+
+func (r *fakeTypeRepository) GetDamageRelations(ctx context.Context, typeID uint) ([]*entity.TypeDamageRelation, error) {
+	return r.relations[typeID], nil
+}
+
+// fakePokemonRepository serves stored Pokemon by ID and name.
+type fakePokemonRepository struct {
+	repository.PokemonRepository
+	pokemons []*entity.Pokemon
+}
+
+func (r *fakePokemonRepository) GetByIDWithRelations(ctx context.Context, id uint) (*entity.Pokemon, error) {
+	for _, pokemon := range r.pokemons {
+		if pokemon.ID == id {
+			return pokemon, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakePokemonRepository) GetByNameWithRelations(ctx context.Context, name string) (*entity.Pokemon, error) {
+	for _, pokemon := range r.pokemons {
+		if pokemon.Name == name {
+			return pokemon, nil
+		}
+	}
+	return nil, nil
+}
+
+func newMatchupUsecase() *usecase {
+	synced := time.Now()
+	types := make(map[string]*entity.Type)
+	for id, name := range []string{"fire", "water", "grass", "ghost", "normal", "steel", "flying", "poison"} {
+		types[name] = &entity.Type{ID: uint(id + 1), Name: name, DamageRelationsSyncedAt: &synced}
+	}
+	// Stored, but its damage relations have not been synced yet
+	types["stellar"] = &entity.Type{ID: 99, Name: "stellar"}
+
+	relation := func(attacker, defender string, multiplier float64) *entity.TypeDamageRelation {
+		return &entity.TypeDamageRelation{
+			AttackingTypeID: types[attacker].ID,
+			DefendingTypeID: types[defender].ID,
+			Multiplier:      multiplier,
+			AttackingType:   *types[attacker],
+			DefendingType:   *types[defender],
+		}
+	}
+
+	return &usecase{
+		typeRepo: &fakeTypeRepository{
+			types: types,
+			relations: map[uint][]*entity.TypeDamageRelation{
+				types["fire"].ID: {
+					relation("fire", "water", entity.DamageMultiplierHalf),
+					relation("fire", "grass", entity.DamageMultiplierDouble),
+					relation("fire", "steel", entity.DamageMultiplierDouble),
+					relation("fire", "fire", entity.DamageMultiplierHalf),
+					// Fire defending must not count when fire attacks
+					relation("water", "fire", entity.DamageMultiplierDouble),
+				},
+				types["normal"].ID: {
+					relation("normal", "ghost", entity.DamageMultiplierNone),
+					relation("normal", "steel", entity.DamageMultiplierHalf),
+				},
+			},
+		},
+		pokemonRepo: &fakePokemonRepository{
+			pokemons: []*entity.Pokemon{
+				{
+					ID:   1,
+					Name: "bulbasaur",
+					Types: []entity.PokemonType{
+						{Slot: 1, Type: *types["grass"]},
+						{Slot: 2, Type: *types["poison"]},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCalculateMatchup(t *testing.T) {
+	tests := []struct {
+		name            string
+		attacker        string
+		pokemon         string
+		defenders       []string
+		wantMultiplier  float64
+		wantMultipliers []entity.TypeMultiplier
+	}{
+		{
+			name:            "super effective",
+			attacker:        "fire",
+			defenders:       []string{"grass"},
+			wantMultiplier:  2,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "grass", Multiplier: 2}},
+		},
+		{
+			name:            "not very effective",
+			attacker:        "fire",
+			defenders:       []string{"water"},
+			wantMultiplier:  0.5,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "water", Multiplier: 0.5}},
+		},
+		{
+			name:            "no relation is neutral",
+			attacker:        "fire",
+			defenders:       []string{"flying"},
+			wantMultiplier:  1,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "flying", Multiplier: 1}},
+		},
+		{
+			name:            "relations defending against the attacker are ignored",
+			attacker:        "fire",
+			defenders:       []string{"fire"},
+			wantMultiplier:  0.5,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "fire", Multiplier: 0.5}},
+		},
+		{
+			name:            "two types multiply",
+			attacker:        "fire",
+			defenders:       []string{"grass", "steel"},
+			wantMultiplier:  4,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "grass", Multiplier: 2}, {Type: "steel", Multiplier: 2}},
+		},
+		{
+			name:            "two types cancel out",
+			attacker:        "fire",
+			defenders:       []string{"water", "grass"},
+			wantMultiplier:  1,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "water", Multiplier: 0.5}, {Type: "grass", Multiplier: 2}},
+		},
+		{
+			name:            "immunity wins",
+			attacker:        "normal",
+			defenders:       []string{"ghost", "steel"},
+			wantMultiplier:  0,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "ghost", Multiplier: 0}, {Type: "steel", Multiplier: 0.5}},
+		},
+		{
+			name:            "names are trimmed and lower cased",
+			attacker:        " Fire ",
+			defenders:       []string{"GRASS"},
+			wantMultiplier:  2,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "grass", Multiplier: 2}},
+		},
+		{
+			name:            "pokemon by name",
+			attacker:        "fire",
+			pokemon:         "Bulbasaur",
+			wantMultiplier:  2,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "grass", Multiplier: 2}, {Type: "poison", Multiplier: 1}},
+		},
+		{
+			name:            "pokemon by ID",
+			attacker:        "fire",
+			pokemon:         "1",
+			wantMultiplier:  2,
+			wantMultipliers: []entity.TypeMultiplier{{Type: "grass", Multiplier: 2}, {Type: "poison", Multiplier: 1}},
+		},
+	}
+
+	u := newMatchupUsecase()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchup, err := u.CalculateMatchup(tt.attacker, tt.pokemon, tt.defenders)
+			if err != nil {
+				t.Fatalf("CalculateMatchup: %v", err)
+			}
+			if matchup.Multiplier != tt.wantMultiplier {
+				t.Errorf("multiplier = %v, want %v", matchup.Multiplier, tt.wantMultiplier)
+			}
+			if !reflect.DeepEqual(matchup.DefendingTypes, tt.wantMultipliers) {
+				t.Errorf("defending types = %+v, want %+v", matchup.DefendingTypes, tt.wantMultipliers)
+			}
+			if (tt.pokemon != "") != (matchup.DefendingPokemon != nil) {
+				t.Errorf("defending pokemon = %v, want one only when given", matchup.DefendingPokemon)
+			}
+		})
+	}
+}
+
+func TestCalculateMatchupRejects(t *testing.T) {
+	tests := []struct {
+		name      string
+		attacker  string
+		pokemon   string
+		defenders []string
+		want      error
+	}{
+		{"unknown attacker", "shadow", "", []string{"grass"}, ErrTypeNotFound},
+		{"unknown defender", "fire", "", []string{"shadow"}, ErrTypeNotFound},
+		{"unknown pokemon", "fire", "pikachu", nil, ErrPokemonNotFound},
+		{"no defender", "fire", "", nil, ErrInvalidMatchup},
+		{"three defenders", "fire", "", []string{"grass", "steel", "water"}, ErrInvalidMatchup},
+		{"attacker not synced", "stellar", "", []string{"grass"}, ErrDamageRelationsNotSynced},
+	}
+
+	u := newMatchupUsecase()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := u.CalculateMatchup(tt.attacker, tt.pokemon, tt.defenders); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -2,114 +2,267 @@ package pokemon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AhmadNizar/cata-dtc/internal/entity"
 	"github.com/AhmadNizar/cata-dtc/internal/repository"
 )
 
+// ErrPokemonNotFound is returned when a Pokemon does not exist.
+var ErrPokemonNotFound = errors.New("pokemon not found")
+
+// SyncConfig selects which upstream Pokemon a sync ingests. EndID of 0
+// means there is no upper bound, and an empty IDs allow-list means every
+// Pokemon in range is synced.
+type SyncConfig struct {
+	StartID  int
+	EndID    int
+	PageSize int
+	IDs      []int
+	// Concurrency is the number of workers fetching and saving Pokemon
+	// in parallel.
+	Concurrency int
+	// LockTTL is the lease on the cluster-wide sync lock. It is renewed
+	// while the sync runs.
+	LockTTL time.Duration
+}
+
 type usecase struct {
-	pokemonRepo   repository.PokemonRepository
-	pokemonAPIRepo repository.PokemonAPIRepository
-	cache         repository.CacheRepository
-	cacheTTL      time.Duration
+	pokemonRepo        repository.PokemonRepository
+	pokemonAPIRepo     repository.PokemonAPIRepository
+	speciesRepo        repository.PokemonSpeciesRepository
+	evolutionChainRepo repository.EvolutionChainRepository
+	moveRepo           repository.MoveRepository
+	pokemonMoveRepo    repository.PokemonMoveRepository
+	typeRepo           repository.TypeRepository
+	pokemonAbilityRepo repository.PokemonAbilityRepository
+	pokemonHistoryRepo repository.PokemonHistoryRepository
+	syncRunRepo        repository.SyncRunRepository
+	lockRepo           repository.LockRepository
+	fenceRepo          repository.FenceRepository
+	cache              repository.CacheRepository
+	sprites            repository.BlobRepository
+	cacheTTL           time.Duration
+	languages          []string
+	syncConfig         SyncConfig
+	jobs               *syncJobTracker
 }
 
 func NewUsecase(
 	pokemonRepo repository.PokemonRepository,
 	pokemonAPIRepo repository.PokemonAPIRepository,
+	speciesRepo repository.PokemonSpeciesRepository,
+	evolutionChainRepo repository.EvolutionChainRepository,
+	moveRepo repository.MoveRepository,
+	pokemonMoveRepo repository.PokemonMoveRepository,
+	typeRepo repository.TypeRepository,
+	pokemonAbilityRepo repository.PokemonAbilityRepository,
+	pokemonHistoryRepo repository.PokemonHistoryRepository,
+	syncRunRepo repository.SyncRunRepository,
+	lockRepo repository.LockRepository,
+	fenceRepo repository.FenceRepository,
 	cache repository.CacheRepository,
+	sprites repository.BlobRepository,
 	cacheTTL time.Duration,
+	languages []string,
+	syncConfig SyncConfig,
 ) Service {
+	// Requests in no configured language are served in the default one,
+	// so its cache entries exist whatever is configured
+	if !slices.Contains(languages, entity.DefaultLanguage) {
+		languages = append(slices.Clone(languages), entity.DefaultLanguage)
+	}
+	if syncConfig.StartID <= 0 {
+		syncConfig.StartID = 1
+	}
+	if syncConfig.PageSize <= 0 {
+		syncConfig.PageSize = 100
+	}
+	if syncConfig.Concurrency <= 0 {
+		syncConfig.Concurrency = 1
+	}
+	if syncConfig.LockTTL <= 0 {
+		syncConfig.LockTTL = 30 * time.Second
+	}
+
 	return &usecase{
-		pokemonRepo:   pokemonRepo,
-		pokemonAPIRepo: pokemonAPIRepo,
-		cache:         cache,
-		cacheTTL:      cacheTTL,
+		pokemonRepo:        pokemonRepo,
+		pokemonAPIRepo:     pokemonAPIRepo,
+		speciesRepo:        speciesRepo,
+		evolutionChainRepo: evolutionChainRepo,
+		moveRepo:           moveRepo,
+		pokemonMoveRepo:    pokemonMoveRepo,
+		typeRepo:           typeRepo,
+		pokemonAbilityRepo: pokemonAbilityRepo,
+		pokemonHistoryRepo: pokemonHistoryRepo,
+		syncRunRepo:        syncRunRepo,
+		lockRepo:           lockRepo,
+		fenceRepo:          fenceRepo,
+		cache:              cache,
+		sprites:            sprites,
+		cacheTTL:           cacheTTL,
+		languages:          languages,
+		syncConfig:         syncConfig,
+		jobs:               newSyncJobTracker(),
 	}
 }
 
-func (u *usecase) SyncPokemonData() error {
-	ctx := context.Background()
+// GetPokemonItems returns a page of the Pokemon selected by filter, sorted
+// by order, with the names of their species, types and abilities in
+// language, along with how many Pokemon the filter selects in total and
+// the cursor of the next page, which is nil on the last page. Each page is
+// cached on its own.
+func (u *usecase) GetPokemonItems(language string, filter repository.PokemonFilter, order repository.PokemonSort, limit, offset int) ([]*entity.Pokemon, int64, *repository.PokemonCursor, error) {
+	ctx := withLanguage(context.Background(), language)
+	cacheKey := fmt.Sprintf("pokemon:list:%s:%s:sort=%s:%d:%d", language, pokemonFilterCacheKey(filter), order, limit, offset)
 
-	log.Println("Starting Pokemon data sync...")
+	pokemons, total, err := u.listPokemonItems(ctx, cacheKey, filter, func() ([]*entity.Pokemon, error) {
+		return u.pokemonRepo.ListWithRelations(ctx, filter, order, limit, offset)
+	})
+	if err != nil {
+		return nil, 0, nil, err
+	}
 
-	successCount := 0
-	errorCount := 0
+	var next *repository.PokemonCursor
+	if len(pokemons) > 0 && int64(offset+len(pokemons)) < total {
+		next = pokemonCursorAfter(pokemons[len(pokemons)-1], order)
+	}
+	return pokemons, total, next, nil
+}
 
-	for i := 1; i <= 20; i++ {
-		log.Printf("Fetching Pokemon ID: %d", i)
+// GetPokemonItemsAfter is GetPokemonItems for the page that starts after a
+// cursor rather than at an offset. The cursor must come from a page with
+// the same sort.
+func (u *usecase) GetPokemonItemsAfter(language string, filter repository.PokemonFilter, order repository.PokemonSort, after repository.PokemonCursor, limit int) ([]*entity.Pokemon, int64, *repository.PokemonCursor, error) {
+	ctx := withLanguage(context.Background(), language)
+	cacheKey := fmt.Sprintf("pokemon:list:%s:%s:sort=%s:%d:after=%s", language, pokemonFilterCacheKey(filter), order, limit, pokemonCursorCacheKey(after))
 
-		pokemonData, err := u.pokemonAPIRepo.GetPokemon(ctx, i)
-		if err != nil {
-			log.Printf("❌ Error fetching Pokemon ID %d: %v", i, err)
-			errorCount++
-			continue
-		}
+	// One Pokemon past the page tells whether there is a next page.
+	pokemons, total, err := u.listPokemonItems(ctx, cacheKey, filter, func() ([]*entity.Pokemon, error) {
+		return u.pokemonRepo.ListWithRelationsAfter(ctx, filter, order, after, limit+1)
+	})
+	if err != nil {
+		return nil, 0, nil, err
+	}
 
-		pokemon := convertAPIResponseToPokemon(pokemonData)
+	var next *repository.PokemonCursor
+	if len(pokemons) > limit {
+		pokemons = pokemons[:limit]
+		next = pokemonCursorAfter(pokemons[len(pokemons)-1], order)
+	}
+	return pokemons, total, next, nil
+}
 
-		if err := u.pokemonRepo.CreateOrUpdate(ctx, pokemon); err != nil {
-			log.Printf("❌ Error saving Pokemon ID %d: %v", i, err)
-			errorCount++
-			continue
+// listPokemonItems returns the Pokemon cached under cacheKey, or fetches
+// and caches them, along with how many Pokemon filter selects in total.
+func (u *usecase) listPokemonItems(ctx context.Context, cacheKey string, filter repository.PokemonFilter, fetch func() ([]*entity.Pokemon, error)) ([]*entity.Pokemon, int64, error) {
+	var pokemons []*entity.Pokemon
+	if err := u.cache.Get(ctx, cacheKey, &pokemons); err == nil {
+		log.Println("Returning cached Pokemon list")
+	} else {
+		if err.Error() != "cache miss" {
+			log.Printf("Warning: cache error: %v", err)
 		}
 
-		log.Printf("✅ Successfully synced Pokemon: %s", pokemon.Name)
-		successCount++
-	}
+		log.Println("Fetching Pokemon list from database")
+
+		pokemons, err = fetch()
+		if err != nil {
+			return nil, 0, fmt.Errorf("fetching pokemons: %w", err)
+		}
 
-	if err := u.cache.DeleteByPattern(ctx, "pokemon:*"); err != nil {
-		log.Printf("Warning: failed to invalidate cache: %v", err)
+		if err := u.cache.Set(ctx, cacheKey, pokemons, u.cacheTTL); err != nil {
+			log.Printf("Warning: failed to cache result: %v", err)
+		}
 	}
 
-	if successCount == 0 {
-		log.Printf("❌ Pokemon data sync FAILED: 0 success, %d errors", errorCount)
-		return fmt.Errorf("all pokemon sync attempts failed")
-	} else if errorCount > 0 {
-		log.Printf("⚠️ Pokemon data sync PARTIAL: %d success, %d errors", successCount, errorCount)
-	} else {
-		log.Printf("✅ Pokemon data sync COMPLETED: %d success, 0 errors", successCount)
+	total, err := u.pokemonRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting pokemons: %w", err)
 	}
 
-	return nil
+	return pokemons, total, nil
 }
 
-func (u *usecase) GetPokemonItems() ([]*entity.Pokemon, int64, error) {
-	ctx := context.Background()
-	cacheKey := "pokemon:list"
+// pokemonCursorAfter is the cursor of the page that follows pokemon when
+// sorted by order.
+func pokemonCursorAfter(pokemon *entity.Pokemon, order repository.PokemonSort) *repository.PokemonCursor {
+	return &repository.PokemonCursor{ID: pokemon.ID, Values: order.ValuesOf(pokemon)}
+}
 
-	var cachedPokemons []*entity.Pokemon
-	if err := u.cache.Get(ctx, cacheKey, &cachedPokemons); err == nil {
-		log.Println("Returning cached Pokemon list")
-		total, err := u.pokemonRepo.Count(ctx)
-		if err != nil {
-			return nil, 0, fmt.Errorf("counting pokemons: %w", err)
-		}
-		return cachedPokemons, total, nil
-	} else if err.Error() != "cache miss" {
-		log.Printf("Warning: cache error: %v", err)
+// pokemonCursorCacheKey names the position of a cursor, e.g. "100,pikachu,25".
+func pokemonCursorCacheKey(cursor repository.PokemonCursor) string {
+	parts := make([]string, 0, len(cursor.Values)+1)
+	for _, value := range cursor.Values {
+		parts = append(parts, url.QueryEscape(fmt.Sprint(value)))
 	}
+	return strings.Join(append(parts, strconv.FormatUint(uint64(cursor.ID), 10)), ",")
+}
 
-	log.Println("Fetching Pokemon list from database")
-
-	pokemons, err := u.pokemonRepo.ListWithRelations(ctx, 0, 0)
-	if err != nil {
-		return nil, 0, fmt.Errorf("fetching pokemons: %w", err)
+// pokemonFilterCacheKey names the Pokemon a filter selects, e.g.
+// "default", "forms" or "form=alola", followed by the other conditions in
+// a fixed order, e.g. "default?ability=blaze&type=any:fire".
+func pokemonFilterCacheKey(filter repository.PokemonFilter) string {
+	key := "default"
+	switch {
+	case filter.Form != "":
+		key = "form=" + filter.Form
+	case filter.IncludeForms:
+		key = "forms"
 	}
 
-	total, err := u.pokemonRepo.Count(ctx)
-	if err != nil {
-		return nil, 0, fmt.Errorf("counting pokemons: %w", err)
+	conditions := url.Values{}
+	if len(filter.Types) > 0 {
+		types := append([]string(nil), filter.Types...)
+		sort.Strings(types)
+		match := "any"
+		if filter.AllTypes {
+			match = "all"
+		}
+		conditions.Set("type", match+":"+strings.Join(types, ","))
+	}
+	if filter.Ability != "" {
+		conditions.Set("ability", filter.Ability)
+	}
+	if filter.HiddenAbility != nil {
+		conditions.Set("hidden_ability", strconv.FormatBool(*filter.HiddenAbility))
+	}
+	for name, bounds := range map[string]repository.IntRange{
+		"height":          filter.Height,
+		"weight":          filter.Weight,
+		"base_experience": filter.BaseExp,
+		"order":           filter.Order,
+	} {
+		if bounds.Min != nil {
+			conditions.Set("min_"+name, strconv.Itoa(*bounds.Min))
+		}
+		if bounds.Max != nil {
+			conditions.Set("max_"+name, strconv.Itoa(*bounds.Max))
+		}
 	}
 
-	if err := u.cache.Set(ctx, cacheKey, pokemons, u.cacheTTL); err != nil {
-		log.Printf("Warning: failed to cache result: %v", err)
+	if len(conditions) == 0 {
+		return key
 	}
+	return key + "?" + conditions.Encode()
+}
 
-	return pokemons, total, nil
+// pokemonIDCacheKey and pokemonNameCacheKey name the detail entries of a
+// Pokemon, one per language.
+func pokemonIDCacheKey(id uint, language string) string {
+	return fmt.Sprintf("pokemon:id:%d:%s", id, language)
+}
+
+func pokemonNameCacheKey(name, language string) string {
+	return fmt.Sprintf("pokemon:name:%s:%s", name, language)
 }
 
 func convertAPIResponseToPokemon(apiResponse *entity.PokemonAPIResponse) *entity.Pokemon {
@@ -120,22 +273,39 @@ func convertAPIResponseToPokemon(apiResponse *entity.PokemonAPIResponse) *entity
 		Weight:   apiResponse.Weight,
 		BaseExp:  apiResponse.BaseExperience,
 		OrderNum: apiResponse.Order,
+		Sprites:  apiResponse.Sprites,
 	}
+	pokemon.SpeciesID = optionalResourceID(apiResponse.Species)
+	pokemon.IsDefault = apiResponse.IsDefault
+	pokemon.FormName = formName(apiResponse.Name, apiResponse.Species.Name)
+	pokemon.Moves = convertPokemonMoves(pokemon.ID, apiResponse.Moves)
 
 	// Convert types
 	for _, typeAPI := range apiResponse.Types {
 		pokemon.Types = append(pokemon.Types, entity.PokemonType{
 			PokemonID: pokemon.ID,
-			TypeName:  typeAPI.Type.Name,
+			Type:      entity.Type{Name: typeAPI.Type.Name},
+			Slot:      typeAPI.Slot,
 		})
 	}
 
 	// Convert abilities
 	for _, abilityAPI := range apiResponse.Abilities {
 		pokemon.Abilities = append(pokemon.Abilities, entity.PokemonAbility{
-			PokemonID:   pokemon.ID,
-			AbilityName: abilityAPI.Ability.Name,
-			IsHidden:    abilityAPI.IsHidden,
+			PokemonID: pokemon.ID,
+			Ability:   entity.Ability{Name: abilityAPI.Ability.Name},
+			IsHidden:  abilityAPI.IsHidden,
+			Slot:      abilityAPI.Slot,
+		})
+	}
+
+	// Convert stats
+	for _, statAPI := range apiResponse.Stats {
+		pokemon.Stats = append(pokemon.Stats, entity.PokemonStat{
+			PokemonID: pokemon.ID,
+			StatName:  statAPI.Stat.Name,
+			BaseStat:  statAPI.BaseStat,
+			Effort:    statAPI.Effort,
 		})
 	}
 